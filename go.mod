@@ -2,9 +2,9 @@ module github.com/bherville/pterodactyl-sdk-go
 
 go 1.20
 
+require github.com/sirupsen/logrus v1.9.3
+
 require (
 	github.com/inconshreveable/mousetrap v1.1.0 // indirect
-	github.com/sirupsen/logrus v1.9.3 // indirect
 	golang.org/x/sys v0.0.0-20220715151400-c0bba94af5f8 // indirect
 )
-
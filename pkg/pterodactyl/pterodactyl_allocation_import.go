@@ -0,0 +1,169 @@
+package pterodactyl
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	AllocationPortFloor int = 1024
+	AllocationPortCeil  int = 65535
+	// AllocationRangeLimit is the most ports the panel accepts in a single ports entry
+	AllocationRangeLimit int = 1000
+)
+
+type AllocationImportOptions struct {
+	Alias    string
+	Progress func(progress AllocationImportProgress)
+}
+
+type AllocationImportProgress struct {
+	IP       string
+	Created  int
+	Skipped  int
+	Done     int
+	TotalIPs int
+}
+
+type AllocationImportResult struct {
+	Created int
+	Skipped int
+}
+
+// ParsePortRanges expands expressions like "27015-27030,28015" into sorted, unique ports.
+func ParsePortRanges(expression string) ([]int, error) {
+	unique := map[int]bool{}
+
+	for _, part := range strings.Split(expression, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		bounds := strings.SplitN(part, "-", 2)
+		start, err := strconv.Atoi(strings.TrimSpace(bounds[0]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid port '%s'", part)
+		}
+		end := start
+		if len(bounds) == 2 {
+			end, err = strconv.Atoi(strings.TrimSpace(bounds[1]))
+			if err != nil {
+				return nil, fmt.Errorf("invalid port range '%s'", part)
+			}
+		}
+
+		if start > end {
+			return nil, fmt.Errorf("invalid port range '%s'", part)
+		}
+		if start < AllocationPortFloor || end > AllocationPortCeil {
+			return nil, fmt.Errorf("port range '%s' is outside %d-%d", part, AllocationPortFloor, AllocationPortCeil)
+		}
+
+		for port := start; port <= end; port++ {
+			unique[port] = true
+		}
+	}
+
+	var ports []int
+	for port := range unique {
+		ports = append(ports, port)
+	}
+	sort.Ints(ports)
+
+	return ports, nil
+}
+
+// compressPorts turns sorted ports back into the fewest range entries the panel accepts.
+func compressPorts(ports []int) []string {
+	var ranges []string
+
+	for i := 0; i < len(ports); {
+		j := i
+		for j+1 < len(ports) && ports[j+1] == ports[j]+1 && ports[j+1]-ports[i] < AllocationRangeLimit {
+			j++
+		}
+
+		if i == j {
+			ranges = append(ranges, strconv.Itoa(ports[i]))
+		} else {
+			ranges = append(ranges, fmt.Sprintf("%d-%d", ports[i], ports[j]))
+		}
+		i = j + 1
+	}
+
+	return ranges
+}
+
+func CreateNodeAllocations(pterodactylServer PterodactylServer, nodeId int, ip string, alias string, ports []string) error {
+	if err := requireFeature(pterodactylServer, FeatureApplicationApi); err != nil {
+		return err
+	}
+
+	data := map[string]string{"ip": ip}
+	if alias != "" {
+		data["alias"] = alias
+	}
+	for i, port := range ports {
+		data[fmt.Sprintf("ports[%d]", i)] = port
+	}
+
+	var response struct{}
+	return callApi(&response, pterodactylServer, http.MethodPost, ApiEndpointNodes, []string{strconv.Itoa(nodeId), ApiEndpointAllocations}, data)
+}
+
+// ImportAllocations creates the ports of a range expression on every IP with one call per IP,
+// skipping allocations that already exist on the node.
+func ImportAllocations(pterodactylServer PterodactylServer, nodeId int, ips []string, portExpression string, options AllocationImportOptions) (AllocationImportResult, error) {
+	var result AllocationImportResult
+
+	ports, err := ParsePortRanges(portExpression)
+	if err != nil {
+		return result, err
+	}
+
+	existing, err := GetNodeAllocations(pterodactylServer, nodeId)
+	if err != nil {
+		return result, err
+	}
+
+	known := map[string]bool{}
+	for _, allocation := range existing {
+		known[fmt.Sprintf("%s:%d", allocation.Attributes.IP, allocation.Attributes.Port)] = true
+	}
+
+	for i, ip := range ips {
+		progress := AllocationImportProgress{IP: ip, Done: i + 1, TotalIPs: len(ips)}
+
+		var missing []int
+		for _, port := range ports {
+			if known[fmt.Sprintf("%s:%d", ip, port)] {
+				progress.Skipped++
+				continue
+			}
+			missing = append(missing, port)
+		}
+
+		if len(missing) > 0 {
+			log.Trace(fmt.Sprintf("ImportAllocations -> Creating %d allocations on '%s'", len(missing), ip))
+			err = CreateNodeAllocations(pterodactylServer, nodeId, ip, options.Alias, compressPorts(missing))
+			if err != nil {
+				return result, err
+			}
+			progress.Created = len(missing)
+		}
+
+		result.Created += progress.Created
+		result.Skipped += progress.Skipped
+		if options.Progress != nil {
+			options.Progress(progress)
+		}
+	}
+
+	return result, nil
+}
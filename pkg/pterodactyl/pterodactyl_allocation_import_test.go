@@ -0,0 +1,63 @@
+package pterodactyl
+
+import (
+	"reflect"
+	"testing"
+)
+
+func portRange(start int, end int) []int {
+	var ports []int
+	for port := start; port <= end; port++ {
+		ports = append(ports, port)
+	}
+	return ports
+}
+
+func TestParsePortRanges(t *testing.T) {
+	ports, err := ParsePortRanges(" 27017, 27015-27016 ,,27016")
+	if err != nil {
+		t.Fatalf("ParsePortRanges() error = %s", err)
+	}
+	if expected := []int{27015, 27016, 27017}; !reflect.DeepEqual(ports, expected) {
+		t.Errorf("ParsePortRanges() = %v, want %v", ports, expected)
+	}
+
+	for _, expression := range []string{"abc", "27015-", "27016-27015", "80", "65000-65536"} {
+		_, err := ParsePortRanges(expression)
+		if err == nil {
+			t.Errorf("ParsePortRanges(%q) error = nil, want an error", expression)
+		}
+	}
+}
+
+func TestCompressPorts(t *testing.T) {
+	tests := map[string]struct {
+		ports    []int
+		expected []string
+	}{
+		"single ports": {
+			ports:    []int{25565, 25567},
+			expected: []string{"25565", "25567"},
+		},
+		"range": {
+			ports:    []int{25565, 25566, 25567, 25600},
+			expected: []string{"25565-25567", "25600"},
+		},
+		"range at the limit": {
+			ports:    portRange(20000, 20000+AllocationRangeLimit-1),
+			expected: []string{"20000-20999"},
+		},
+		"range over the limit": {
+			ports:    portRange(20000, 20000+AllocationRangeLimit),
+			expected: []string{"20000-20999", "21000"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if ranges := compressPorts(test.ports); !reflect.DeepEqual(ranges, test.expected) {
+				t.Errorf("compressPorts() = %v, want %v", ranges, test.expected)
+			}
+		})
+	}
+}
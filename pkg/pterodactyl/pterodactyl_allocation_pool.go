@@ -0,0 +1,214 @@
+package pterodactyl
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+type AllocationPool struct {
+	pterodactylServer PterodactylServer
+	nodeId            int
+
+	mutex       sync.Mutex
+	allocations []Allocation
+	reserved    map[int]bool
+}
+
+func NewAllocationPool(pterodactylServer PterodactylServer, nodeId int) (*AllocationPool, error) {
+	pool := &AllocationPool{
+		pterodactylServer: pterodactylServer,
+		nodeId:            nodeId,
+		reserved:          map[int]bool{},
+	}
+
+	err := pool.Refresh()
+	if err != nil {
+		return nil, err
+	}
+
+	return pool, nil
+}
+
+// Refresh reloads the node allocations from the panel. Reservations are kept until the
+// allocation shows up as assigned or is released.
+func (pool *AllocationPool) Refresh() error {
+	allocations, err := GetNodeAllocations(pool.pterodactylServer, pool.nodeId)
+	if err != nil {
+		return err
+	}
+
+	sort.SliceStable(allocations, func(i, j int) bool {
+		if allocations[i].Attributes.IP != allocations[j].Attributes.IP {
+			return allocations[i].Attributes.IP < allocations[j].Attributes.IP
+		}
+		return allocations[i].Attributes.Port < allocations[j].Attributes.Port
+	})
+
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+
+	pool.allocations = allocations
+	for _, allocation := range allocations {
+		if allocation.Attributes.Assigned {
+			delete(pool.reserved, allocation.Attributes.ID)
+		}
+	}
+
+	return nil
+}
+
+func (pool *AllocationPool) NodeId() int {
+	return pool.nodeId
+}
+
+func (pool *AllocationPool) isFree(allocation Allocation) bool {
+	return !allocation.Attributes.Assigned && !pool.reserved[allocation.Attributes.ID]
+}
+
+func (pool *AllocationPool) Free() []Allocation {
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+
+	var free []Allocation
+	for _, allocation := range pool.allocations {
+		if pool.isFree(allocation) {
+			free = append(free, allocation)
+		}
+	}
+	return free
+}
+
+func (pool *AllocationPool) Assigned() []Allocation {
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+
+	var assigned []Allocation
+	for _, allocation := range pool.allocations {
+		if allocation.Attributes.Assigned {
+			assigned = append(assigned, allocation)
+		}
+	}
+	return assigned
+}
+
+func (pool *AllocationPool) Reserved() []Allocation {
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+
+	var reserved []Allocation
+	for _, allocation := range pool.allocations {
+		if pool.reserved[allocation.Attributes.ID] {
+			reserved = append(reserved, allocation)
+		}
+	}
+	return reserved
+}
+
+func (pool *AllocationPool) ReservePort(ip string, port int) (Allocation, error) {
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+
+	for _, allocation := range pool.allocations {
+		if allocation.Attributes.IP != ip || allocation.Attributes.Port != port {
+			continue
+		}
+
+		if !pool.isFree(allocation) {
+			return Allocation{}, fmt.Errorf("allocation %s:%d is not free", ip, port)
+		}
+
+		pool.reserved[allocation.Attributes.ID] = true
+		return allocation, nil
+	}
+
+	return Allocation{}, fmt.Errorf("allocation %s:%d does not exist on node %d", ip, port, pool.nodeId)
+}
+
+func (pool *AllocationPool) Reserve(count int) ([]Allocation, error) {
+	if count < 1 {
+		return nil, fmt.Errorf("invalid allocation count %d", count)
+	}
+
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+
+	var reserved []Allocation
+	for _, allocation := range pool.allocations {
+		if len(reserved) == count {
+			break
+		}
+		if pool.isFree(allocation) {
+			reserved = append(reserved, allocation)
+		}
+	}
+
+	if len(reserved) < count {
+		return nil, fmt.Errorf("node %d has only %d free allocations, %d requested", pool.nodeId, len(reserved), count)
+	}
+
+	for _, allocation := range reserved {
+		pool.reserved[allocation.Attributes.ID] = true
+	}
+	return reserved, nil
+}
+
+func (pool *AllocationPool) findContiguous(count int) ([]Allocation, error) {
+	var run []Allocation
+
+	if count < 1 {
+		return nil, fmt.Errorf("invalid allocation count %d", count)
+	}
+
+	for _, allocation := range pool.allocations {
+		if !pool.isFree(allocation) {
+			run = nil
+			continue
+		}
+
+		if len(run) > 0 {
+			last := run[len(run)-1].Attributes
+			if last.IP != allocation.Attributes.IP || last.Port+1 != allocation.Attributes.Port {
+				run = nil
+			}
+		}
+
+		run = append(run, allocation)
+		if len(run) == count {
+			return run, nil
+		}
+	}
+
+	return nil, fmt.Errorf("node %d has no %d contiguous free ports", pool.nodeId, count)
+}
+
+func (pool *AllocationPool) FindContiguous(count int) ([]Allocation, error) {
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+
+	return pool.findContiguous(count)
+}
+
+func (pool *AllocationPool) ReserveContiguous(count int) ([]Allocation, error) {
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+
+	allocations, err := pool.findContiguous(count)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, allocation := range allocations {
+		pool.reserved[allocation.Attributes.ID] = true
+	}
+	return allocations, nil
+}
+
+func (pool *AllocationPool) Release(allocations ...Allocation) {
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+
+	for _, allocation := range allocations {
+		delete(pool.reserved, allocation.Attributes.ID)
+	}
+}
@@ -0,0 +1,156 @@
+package pterodactyl
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// allocationPanel serves the allocations of a node, the body can be swapped between refreshes.
+func allocationPanel(t *testing.T, allocations string) (PterodactylServer, func(allocations string)) {
+	t.Helper()
+
+	var mutex sync.Mutex
+	panel := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		fmt.Fprintf(w, `{"data": [%s], "meta": {"pagination": {"total_pages": 1}}}`, allocations)
+	}))
+	t.Cleanup(panel.Close)
+
+	return PterodactylServer{Url: panel.URL}, func(updated string) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		allocations = updated
+	}
+}
+
+func allocationJSON(id int, ip string, port int, assigned bool) string {
+	return fmt.Sprintf(`{"attributes": {"id": %d, "ip": "%s", "port": %d, "assigned": %t}}`, id, ip, port, assigned)
+}
+
+func allocationPorts(allocations []Allocation) []string {
+	var ports []string
+	for _, allocation := range allocations {
+		ports = append(ports, fmt.Sprintf("%s:%d", allocation.Attributes.IP, allocation.Attributes.Port))
+	}
+	return ports
+}
+
+func TestAllocationPoolContiguous(t *testing.T) {
+	pterodactylServer, _ := allocationPanel(t, strings.Join([]string{
+		allocationJSON(1, "10.0.0.1", 25565, false),
+		allocationJSON(2, "10.0.0.1", 25566, false),
+		// The run breaks across IPs even though the ports follow each other
+		allocationJSON(3, "10.0.0.2", 25567, false),
+		allocationJSON(4, "10.0.0.2", 25568, false),
+		// A gap in the ports
+		allocationJSON(5, "10.0.0.2", 25570, false),
+		allocationJSON(6, "10.0.0.2", 25571, false),
+		allocationJSON(7, "10.0.0.2", 25572, false),
+		// An assigned port in the middle of a run
+		allocationJSON(8, "10.0.0.3", 25565, false),
+		allocationJSON(9, "10.0.0.3", 25566, true),
+		allocationJSON(10, "10.0.0.3", 25567, false),
+	}, ","))
+
+	pool, err := NewAllocationPool(pterodactylServer, 1)
+	if err != nil {
+		t.Fatalf("NewAllocationPool() error = %s", err)
+	}
+
+	tests := map[string]struct {
+		count    int
+		expected []string
+	}{
+		"two":   {count: 2, expected: []string{"10.0.0.1:25565", "10.0.0.1:25566"}},
+		"three": {count: 3, expected: []string{"10.0.0.2:25570", "10.0.0.2:25571", "10.0.0.2:25572"}},
+		"four":  {count: 4, expected: nil},
+		"zero":  {count: 0, expected: nil},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			allocations, err := pool.FindContiguous(test.count)
+			if ports := allocationPorts(allocations); !reflect.DeepEqual(ports, test.expected) {
+				t.Errorf("FindContiguous(%d) = %v, want %v", test.count, ports, test.expected)
+			}
+			if (err != nil) != (test.expected == nil) {
+				t.Errorf("FindContiguous(%d) error = %v", test.count, err)
+			}
+		})
+	}
+
+	reserved, err := pool.ReserveContiguous(2)
+	if err != nil {
+		t.Fatalf("ReserveContiguous() error = %s", err)
+	}
+	// The first run is reserved now, so the next one starts on the second IP
+	next, err := pool.ReserveContiguous(2)
+	if err != nil {
+		t.Fatalf("ReserveContiguous() error = %s", err)
+	}
+	if ports := allocationPorts(append(reserved, next...)); !reflect.DeepEqual(ports, []string{"10.0.0.1:25565", "10.0.0.1:25566", "10.0.0.2:25567", "10.0.0.2:25568"}) {
+		t.Errorf("ReserveContiguous() reserved %v", ports)
+	}
+}
+
+func TestAllocationPoolReserve(t *testing.T) {
+	pterodactylServer, update := allocationPanel(t, strings.Join([]string{
+		allocationJSON(1, "10.0.0.1", 25567, false),
+		allocationJSON(2, "10.0.0.1", 25565, true),
+		allocationJSON(3, "10.0.0.1", 25566, false),
+		allocationJSON(4, "10.0.0.1", 25568, false),
+	}, ","))
+
+	pool, err := NewAllocationPool(pterodactylServer, 1)
+	if err != nil {
+		t.Fatalf("NewAllocationPool() error = %s", err)
+	}
+
+	reserved, err := pool.Reserve(2)
+	if err != nil {
+		t.Fatalf("Reserve() error = %s", err)
+	}
+	if ports := allocationPorts(reserved); !reflect.DeepEqual(ports, []string{"10.0.0.1:25566", "10.0.0.1:25567"}) {
+		t.Errorf("Reserve() = %v, want the two lowest free ports", ports)
+	}
+	if ports := allocationPorts(pool.Free()); !reflect.DeepEqual(ports, []string{"10.0.0.1:25568"}) {
+		t.Errorf("Free() = %v, want the port that is neither assigned nor reserved", ports)
+	}
+
+	_, err = pool.Reserve(2)
+	if err == nil {
+		t.Error("Reserve() of more than the free ports error = nil, want an error")
+	}
+	_, err = pool.ReservePort("10.0.0.1", 25566)
+	if err == nil {
+		t.Error("ReservePort() of a reserved port error = nil, want an error")
+	}
+
+	// The server using 25566 was created, 25567 is still only reserved
+	update(strings.Join([]string{
+		allocationJSON(1, "10.0.0.1", 25567, false),
+		allocationJSON(2, "10.0.0.1", 25565, true),
+		allocationJSON(3, "10.0.0.1", 25566, true),
+		allocationJSON(4, "10.0.0.1", 25568, false),
+	}, ","))
+	err = pool.Refresh()
+	if err != nil {
+		t.Fatalf("Refresh() error = %s", err)
+	}
+	if ports := allocationPorts(pool.Reserved()); !reflect.DeepEqual(ports, []string{"10.0.0.1:25567"}) {
+		t.Errorf("Reserved() after Refresh() = %v, want the port not assigned yet", ports)
+	}
+
+	pool.Release(reserved...)
+	if ports := allocationPorts(pool.Free()); !reflect.DeepEqual(ports, []string{"10.0.0.1:25567", "10.0.0.1:25568"}) {
+		t.Errorf("Free() after Release() = %v", ports)
+	}
+}
@@ -1,15 +1,44 @@
 package pterodactyl
 
 import (
+	"net/http"
 	"time"
 )
 
+// PterodactylServer describes a panel and how to call it. It is passed by value and never
+// modified by the SDK, so a single value can be used from several goroutines. The state it
+// shares between calls, such as the circuit breaker or the egg cache, lives behind pointer
+// fields that are safe for concurrent use.
 type PterodactylServer struct {
 	ApiKey string `json:"apiKey"`
 	Name   string `json:"name"`
 	Url    string `json:"url"`
+	// Flavor enables the fallbacks for the fork the panel runs, see DetectPanelFlavor.
+	Flavor string `json:"flavor,omitempty"`
+	// Features overrides detected or assumed endpoint support, see Supports.
+	Features map[Feature]bool `json:"features,omitempty"`
+	// BackupNameTemplate names the backups created by the SDK, see RenderBackupName.
+	BackupNameTemplate string `json:"backupNameTemplate,omitempty"`
+	// Policy guards destructive calls, they are not restricted when it is nil.
+	Policy *DestructivePolicy `json:"policy,omitempty"`
+	// CircuitBreaker makes calls fail fast with ErrCircuitOpen while the panel is down.
+	CircuitBreaker *CircuitBreaker `json:"-"`
+	// Clock replaces the system clock in wait helpers and retry backoffs.
+	Clock Clock `json:"-"`
+	// HTTPClient sends the API, download and upload requests, a client owned by the SDK is
+	// used when it is nil.
+	HTTPClient *http.Client `json:"-"`
+	// Coalescer shares the response of identical GET requests sent concurrently, each request
+	// is sent on its own when it is nil.
+	Coalescer *RequestCoalescer `json:"-"`
+	// EggCache keeps the eggs resolved by FindEgg, eggs are not cached when it is nil.
+	EggCache *EggCache `json:"-"`
+	// OnResponse is called with the metadata of every API response, including failed ones.
+	// Callers sharing a response through the Coalescer are each called with it.
+	OnResponse func(method string, url string, response ApiResponse) `json:"-"`
 }
 
+// Deprecated: decode into ListResponse[Server] instead.
 type Servers struct {
 	Object  string      `json:"object"`
 	Servers []Server    `json:"data"`
@@ -53,24 +82,17 @@ type Server struct {
 			IP   string `json:"ip"`
 			Port int    `json:"port"`
 		} `json:"sftp_details"`
-		Description string `json:"description"`
-		Limits      struct {
-			Memory      int  `json:"memory"`
-			Swap        int  `json:"swap"`
-			Disk        int  `json:"disk"`
-			Io          int  `json:"io"`
-			CPU         int  `json:"cpu"`
-			Threads     any  `json:"threads"`
-			OomDisabled bool `json:"oom_disabled"`
-		} `json:"limits"`
-		Invocation    string   `json:"invocation"`
-		DockerImage   string   `json:"docker_image"`
-		EggFeatures   []string `json:"egg_features"`
+		Description   string       `json:"description"`
+		Limits        ServerLimits `json:"limits"`
+		Invocation    string       `json:"invocation"`
+		DockerImage   string       `json:"docker_image"`
+		EggFeatures   []string     `json:"egg_features"`
 		FeatureLimits struct {
 			Databases   int `json:"databases"`
 			Allocations int `json:"allocations"`
 			Backups     int `json:"backups"`
 		} `json:"feature_limits"`
+		// Status is null for a server that can be acted on, read it through Server.Status.
 		Status         any  `json:"status"`
 		IsSuspended    bool `json:"is_suspended"`
 		IsInstalling   bool `json:"is_installing"`
@@ -80,34 +102,20 @@ type Server struct {
 		Bg             any  `json:"bg"`
 		Relationships  struct {
 			Allocations struct {
-				Object string `json:"object"`
-				Data   []struct {
-					Object     string `json:"object"`
-					Attributes struct {
-						ID        int    `json:"id"`
-						IP        string `json:"ip"`
-						IPAlias   string `json:"ip_alias"`
-						Port      int    `json:"port"`
-						Notes     any    `json:"notes"`
-						IsDefault bool   `json:"is_default"`
-					} `json:"attributes"`
-				} `json:"data"`
+				Object string             `json:"object"`
+				Data   []ServerAllocation `json:"data"`
 			} `json:"allocations"`
 			Variables struct {
-				Object string `json:"object"`
-				Data   []struct {
-					Object     string `json:"object"`
-					Attributes struct {
-						Name         string `json:"name"`
-						Description  string `json:"description"`
-						EnvVariable  string `json:"env_variable"`
-						DefaultValue string `json:"default_value"`
-						ServerValue  string `json:"server_value"`
-						IsEditable   bool   `json:"is_editable"`
-						Rules        string `json:"rules"`
-					} `json:"attributes"`
-				} `json:"data"`
+				Object string           `json:"object"`
+				Data   []ServerVariable `json:"data"`
 			} `json:"variables"`
+			Egg struct {
+				Object     string `json:"object"`
+				Attributes struct {
+					UUID string `json:"uuid"`
+					Name string `json:"name"`
+				} `json:"attributes"`
+			} `json:"egg"`
 		} `json:"relationships"`
 	} `json:"attributes"`
 	Meta struct {
@@ -116,6 +124,52 @@ type Server struct {
 	} `json:"meta"`
 }
 
+type ServerLimits struct {
+	Memory      int  `json:"memory"`
+	Swap        int  `json:"swap"`
+	Disk        int  `json:"disk"`
+	Io          int  `json:"io"`
+	CPU         int  `json:"cpu"`
+	Threads     any  `json:"threads"`
+	OomDisabled bool `json:"oom_disabled"`
+}
+
+type ServerAllocation struct {
+	Object     string `json:"object"`
+	Attributes struct {
+		ID        int    `json:"id"`
+		IP        string `json:"ip"`
+		IPAlias   string `json:"ip_alias"`
+		Port      int    `json:"port"`
+		Notes     any    `json:"notes"`
+		IsDefault bool   `json:"is_default"`
+	} `json:"attributes"`
+}
+
+type ServerVariable struct {
+	Object     string `json:"object"`
+	Attributes struct {
+		Name         string `json:"name"`
+		Description  string `json:"description"`
+		EnvVariable  string `json:"env_variable"`
+		DefaultValue string `json:"default_value"`
+		ServerValue  string `json:"server_value"`
+		IsEditable   bool   `json:"is_editable"`
+		Rules        string `json:"rules"`
+	} `json:"attributes"`
+}
+
+type ServerStartup struct {
+	Object    string           `json:"object"`
+	Variables []ServerVariable `json:"data"`
+	Meta      struct {
+		StartupCommand    string         `json:"startup_command"`
+		DockerImages      DockerImageMap `json:"docker_images"`
+		RawStartupCommand string         `json:"raw_startup_command"`
+	} `json:"meta"`
+}
+
+// Deprecated: decode into ListResponse[Backup] instead.
 type Backups struct {
 	Object  string      `json:"object"`
 	Backups []Backup    `json:"data"`
@@ -128,6 +182,8 @@ type Backup struct {
 		Name         string    `json:"name"`
 		IgnoredFiles []any     `json:"ignored_files"`
 		Sha256Hash   string    `json:"sha256_hash"`
+		IsSuccessful bool      `json:"is_successful"`
+		IsLocked     bool      `json:"is_locked"`
 		Bytes        int       `json:"bytes"`
 		CreatedAt    time.Time `json:"created_at"`
 		CompletedAt  time.Time `json:"completed_at,omitempty"`
@@ -140,3 +196,143 @@ type BackupUrl struct {
 		URL string `json:"url"`
 	} `json:"attributes"`
 }
+
+type Node struct {
+	Object     string `json:"object"`
+	Attributes struct {
+		ID                 int       `json:"id"`
+		UUID               string    `json:"uuid"`
+		Public             bool      `json:"public"`
+		Name               string    `json:"name"`
+		Description        string    `json:"description"`
+		LocationID         int       `json:"location_id"`
+		Fqdn               string    `json:"fqdn"`
+		Scheme             string    `json:"scheme"`
+		BehindProxy        bool      `json:"behind_proxy"`
+		MaintenanceMode    bool      `json:"maintenance_mode"`
+		Memory             int       `json:"memory"`
+		MemoryOverallocate int       `json:"memory_overallocate"`
+		Disk               int       `json:"disk"`
+		DiskOverallocate   int       `json:"disk_overallocate"`
+		UploadSize         int       `json:"upload_size"`
+		DaemonListen       int       `json:"daemon_listen"`
+		DaemonSftp         int       `json:"daemon_sftp"`
+		DaemonBase         string    `json:"daemon_base"`
+		CreatedAt          time.Time `json:"created_at"`
+		UpdatedAt          time.Time `json:"updated_at"`
+		AllocatedResources struct {
+			Memory int `json:"memory"`
+			Disk   int `json:"disk"`
+		} `json:"allocated_resources"`
+		Relationships struct {
+			Servers struct {
+				Object string              `json:"object"`
+				Data   []ApplicationServer `json:"data"`
+			} `json:"servers"`
+		} `json:"relationships"`
+	} `json:"attributes"`
+}
+
+type Allocation struct {
+	Object     string `json:"object"`
+	Attributes struct {
+		ID       int    `json:"id"`
+		IP       string `json:"ip"`
+		Alias    string `json:"alias"`
+		Port     int    `json:"port"`
+		Notes    string `json:"notes"`
+		Assigned bool   `json:"assigned"`
+	} `json:"attributes"`
+}
+
+type ServerResources struct {
+	Object     string `json:"object"`
+	Attributes struct {
+		CurrentState string `json:"current_state"`
+		IsSuspended  bool   `json:"is_suspended"`
+		Resources    struct {
+			MemoryBytes    int64   `json:"memory_bytes"`
+			CPUAbsolute    float64 `json:"cpu_absolute"`
+			DiskBytes      int64   `json:"disk_bytes"`
+			NetworkRxBytes int64   `json:"network_rx_bytes"`
+			NetworkTxBytes int64   `json:"network_tx_bytes"`
+			Uptime         int64   `json:"uptime"`
+		} `json:"resources"`
+	} `json:"attributes"`
+}
+
+type ApplicationServer struct {
+	Object     string `json:"object"`
+	Attributes struct {
+		ID            int          `json:"id"`
+		ExternalID    string       `json:"external_id"`
+		UUID          string       `json:"uuid"`
+		Identifier    string       `json:"identifier"`
+		Name          string       `json:"name"`
+		Description   string       `json:"description"`
+		Status        string       `json:"status"`
+		Suspended     bool         `json:"suspended"`
+		Limits        ServerLimits `json:"limits"`
+		FeatureLimits struct {
+			Databases   int `json:"databases"`
+			Allocations int `json:"allocations"`
+			Backups     int `json:"backups"`
+		} `json:"feature_limits"`
+		User       int `json:"user"`
+		Node       int `json:"node"`
+		Allocation int `json:"allocation"`
+		Nest       int `json:"nest"`
+		Egg        int `json:"egg"`
+		Container  struct {
+			StartupCommand string         `json:"startup_command"`
+			Image          string         `json:"image"`
+			Installed      int            `json:"installed"`
+			Environment    map[string]any `json:"environment"`
+		} `json:"container"`
+		UpdatedAt time.Time `json:"updated_at"`
+		CreatedAt time.Time `json:"created_at"`
+	} `json:"attributes"`
+}
+
+type Nest struct {
+	Object     string `json:"object"`
+	Attributes struct {
+		ID          int       `json:"id"`
+		UUID        string    `json:"uuid"`
+		Author      string    `json:"author"`
+		Name        string    `json:"name"`
+		Description string    `json:"description"`
+		CreatedAt   time.Time `json:"created_at"`
+		UpdatedAt   time.Time `json:"updated_at"`
+	} `json:"attributes"`
+}
+
+type Egg struct {
+	Object     string `json:"object"`
+	Attributes struct {
+		ID           int            `json:"id"`
+		UUID         string         `json:"uuid"`
+		Name         string         `json:"name"`
+		Nest         int            `json:"nest"`
+		Author       string         `json:"author"`
+		Description  string         `json:"description"`
+		DockerImage  string         `json:"docker_image"`
+		DockerImages DockerImageMap `json:"docker_images"`
+		Startup      string         `json:"startup"`
+		CreatedAt    time.Time      `json:"created_at"`
+		UpdatedAt    time.Time      `json:"updated_at"`
+	} `json:"attributes"`
+}
+
+type Subuser struct {
+	Object     string `json:"object"`
+	Attributes struct {
+		UUID             string    `json:"uuid"`
+		Username         string    `json:"username"`
+		Email            string    `json:"email"`
+		Image            string    `json:"image"`
+		TwoFactorEnabled bool      `json:"2fa_enabled"`
+		CreatedAt        time.Time `json:"created_at"`
+		Permissions      []string  `json:"permissions"`
+	} `json:"attributes"`
+}
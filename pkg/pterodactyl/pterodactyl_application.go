@@ -0,0 +1,267 @@
+package pterodactyl
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+func pageQuery(page int) url.Values {
+	query := url.Values{}
+	query.Set("page", strconv.Itoa(page))
+	query.Set("per_page", strconv.Itoa(ApiMaxPerPage))
+	return query
+}
+
+func GetNodes(pterodactylServer PterodactylServer) ([]Node, error) {
+	if err := requireFeature(pterodactylServer, FeatureApplicationApi); err != nil {
+		return nil, err
+	}
+
+	return getAllPages[Node](pterodactylServer, ApiEndpointNodes, nil, nil)
+}
+
+func GetNode(pterodactylServer PterodactylServer, nodeId int) (Node, error) {
+	if err := requireFeature(pterodactylServer, FeatureApplicationApi); err != nil {
+		return Node{}, err
+	}
+
+	var node ItemResponse[Node]
+	err := callApi(&node, pterodactylServer, http.MethodGet, ApiEndpointNodes, []string{strconv.Itoa(nodeId)}, nil)
+	if err != nil {
+		return node.Data, err
+	}
+
+	return node.Data, nil
+}
+
+func GetNodeAllocations(pterodactylServer PterodactylServer, nodeId int) ([]Allocation, error) {
+	if err := requireFeature(pterodactylServer, FeatureApplicationApi); err != nil {
+		return nil, err
+	}
+
+	return getAllPages[Allocation](pterodactylServer, ApiEndpointNodes, []string{strconv.Itoa(nodeId), ApiEndpointAllocations}, nil)
+}
+
+func GetNodeServers(pterodactylServer PterodactylServer, nodeId int) ([]ApplicationServer, error) {
+	if err := requireFeature(pterodactylServer, FeatureApplicationApi); err != nil {
+		return nil, err
+	}
+
+	var node ItemResponse[Node]
+	query := url.Values{}
+	query.Set("include", "servers")
+
+	err := callApiWithQuery(&node, pterodactylServer, http.MethodGet, ApiEndpointNodes, []string{strconv.Itoa(nodeId)}, query, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return node.Data.Attributes.Relationships.Servers.Data, nil
+}
+
+func SuspendServer(pterodactylServer PterodactylServer, serverId int) error {
+	if err := requireFeature(pterodactylServer, FeatureApplicationApi); err != nil {
+		return err
+	}
+
+	var response struct{}
+	return callApi(&response, pterodactylServer, http.MethodPost, ApiEndpointApplicationServers, []string{strconv.Itoa(serverId), "suspend"}, nil)
+}
+
+func UnsuspendServer(pterodactylServer PterodactylServer, serverId int) error {
+	if err := requireFeature(pterodactylServer, FeatureApplicationApi); err != nil {
+		return err
+	}
+
+	var response struct{}
+	return callApi(&response, pterodactylServer, http.MethodPost, ApiEndpointApplicationServers, []string{strconv.Itoa(serverId), "unsuspend"}, nil)
+}
+
+func GetApplicationServer(pterodactylServer PterodactylServer, serverId int) (ApplicationServer, error) {
+	if err := requireFeature(pterodactylServer, FeatureApplicationApi); err != nil {
+		return ApplicationServer{}, err
+	}
+
+	var server ItemResponse[ApplicationServer]
+	err := callApi(&server, pterodactylServer, http.MethodGet, ApiEndpointApplicationServers, []string{strconv.Itoa(serverId)}, nil)
+	if err != nil {
+		return server.Data, err
+	}
+
+	return server.Data, nil
+}
+
+func DeleteServer(pterodactylServer PterodactylServer, serverId int, force bool) error {
+	if err := requireFeature(pterodactylServer, FeatureApplicationApi); err != nil {
+		return err
+	}
+
+	if pterodactylServer.Policy != nil {
+		server, err := GetApplicationServer(pterodactylServer, serverId)
+		if err != nil {
+			return err
+		}
+
+		err = checkDestructiveOperation(pterodactylServer, DestructiveOperation{Kind: OperationDeleteServer, ServerName: server.Attributes.Name, ServerUUID: server.Attributes.UUID, Target: strconv.Itoa(serverId)})
+		if err != nil {
+			return err
+		}
+	}
+
+	subPaths := []string{strconv.Itoa(serverId)}
+	if force {
+		subPaths = append(subPaths, "force")
+	}
+
+	var response struct{}
+	return callApi(&response, pterodactylServer, http.MethodDelete, ApiEndpointApplicationServers, subPaths, nil)
+}
+
+type ServerDetails struct {
+	Name        string
+	User        int
+	ExternalId  string
+	Description string
+}
+
+func UpdateServerDetails(pterodactylServer PterodactylServer, serverId int, details ServerDetails) (ApplicationServer, error) {
+	if err := requireFeature(pterodactylServer, FeatureApplicationApi); err != nil {
+		return ApplicationServer{}, err
+	}
+
+	data := map[string]string{
+		"name":        details.Name,
+		"user":        strconv.Itoa(details.User),
+		"external_id": details.ExternalId,
+		"description": details.Description,
+	}
+
+	var server ItemResponse[ApplicationServer]
+	err := callApi(&server, pterodactylServer, http.MethodPatch, ApiEndpointApplicationServers, []string{strconv.Itoa(serverId), "details"}, data)
+	if err != nil {
+		return server.Data, err
+	}
+
+	return server.Data, nil
+}
+
+// SetServerExternalId links a server to an external record, an empty id clears the link.
+// The details endpoint replaces every field, so the current values are sent along.
+func SetServerExternalId(pterodactylServer PterodactylServer, serverId int, externalId string) (ApplicationServer, error) {
+	server, err := GetApplicationServer(pterodactylServer, serverId)
+	if err != nil {
+		return server, err
+	}
+
+	return UpdateServerDetails(pterodactylServer, serverId, ServerDetails{
+		Name:        server.Attributes.Name,
+		User:        server.Attributes.User,
+		ExternalId:  externalId,
+		Description: server.Attributes.Description,
+	})
+}
+
+func formBool(value bool) string {
+	if value {
+		return "1"
+	}
+	return "0"
+}
+
+// UpdateNode sends every editable attribute of the node, the panel validates them as a whole.
+func UpdateNode(pterodactylServer PterodactylServer, node Node) (Node, error) {
+	if err := requireFeature(pterodactylServer, FeatureApplicationApi); err != nil {
+		return Node{}, err
+	}
+
+	attributes := node.Attributes
+	data := map[string]string{
+		"name":                attributes.Name,
+		"description":         attributes.Description,
+		"location_id":         strconv.Itoa(attributes.LocationID),
+		"public":              formBool(attributes.Public),
+		"fqdn":                attributes.Fqdn,
+		"scheme":              attributes.Scheme,
+		"behind_proxy":        formBool(attributes.BehindProxy),
+		"maintenance_mode":    formBool(attributes.MaintenanceMode),
+		"memory":              strconv.Itoa(attributes.Memory),
+		"memory_overallocate": strconv.Itoa(attributes.MemoryOverallocate),
+		"disk":                strconv.Itoa(attributes.Disk),
+		"disk_overallocate":   strconv.Itoa(attributes.DiskOverallocate),
+		"upload_size":         strconv.Itoa(attributes.UploadSize),
+		"daemon_listen":       strconv.Itoa(attributes.DaemonListen),
+		"daemon_sftp":         strconv.Itoa(attributes.DaemonSftp),
+		"daemon_base":         attributes.DaemonBase,
+	}
+
+	var updated ItemResponse[Node]
+	err := callApi(&updated, pterodactylServer, http.MethodPatch, ApiEndpointNodes, []string{strconv.Itoa(attributes.ID)}, data)
+	if err != nil {
+		return updated.Data, err
+	}
+
+	return updated.Data, nil
+}
+
+func SetNodeMaintenanceMode(pterodactylServer PterodactylServer, nodeId int, enabled bool) (Node, error) {
+	node, err := GetNode(pterodactylServer, nodeId)
+	if err != nil {
+		return node, err
+	}
+
+	if node.Attributes.MaintenanceMode == enabled {
+		return node, nil
+	}
+
+	node.Attributes.MaintenanceMode = enabled
+	return UpdateNode(pterodactylServer, node)
+}
+
+func GetNodesInMaintenance(pterodactylServer PterodactylServer) ([]Node, error) {
+	nodes, err := GetNodes(pterodactylServer)
+	if err != nil {
+		return nil, err
+	}
+
+	var inMaintenance []Node
+	for _, node := range nodes {
+		if node.Attributes.MaintenanceMode {
+			inMaintenance = append(inMaintenance, node)
+		}
+	}
+
+	return inMaintenance, nil
+}
+
+// FindNode resolves a node by its FQDN or name, ignoring case. FQDN matches take precedence
+// since node names are not unique on the panel.
+func FindNode(pterodactylServer PterodactylServer, fqdnOrName string) (Node, error) {
+	nodes, err := GetNodes(pterodactylServer)
+	if err != nil {
+		return Node{}, err
+	}
+
+	for _, node := range nodes {
+		if strings.EqualFold(node.Attributes.Fqdn, fqdnOrName) {
+			return node, nil
+		}
+	}
+
+	var matches []Node
+	for _, node := range nodes {
+		if strings.EqualFold(node.Attributes.Name, fqdnOrName) {
+			matches = append(matches, node)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return Node{}, fmt.Errorf("node '%s' not found", fqdnOrName)
+	case 1:
+		return matches[0], nil
+	}
+	return Node{}, fmt.Errorf("several nodes are named '%s'", fqdnOrName)
+}
@@ -0,0 +1,117 @@
+package pterodactyl
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	BackupNameDateLayout string = "2006-01-02"
+	BackupNameTimeLayout string = "15-04-05"
+)
+
+var backupNamePlaceholders = map[string]string{
+	"{server}": `(?P<server>.+?)`,
+	"{date}":   `(?P<date>\d{4}-\d{2}-\d{2})`,
+	"{time}":   `(?P<time>\d{2}-\d{2}-\d{2})`,
+	"{tag}":    `(?P<tag>.*?)`,
+}
+
+var backupNamePlaceholder = regexp.MustCompile(`\{(server|date|time|tag)\}`)
+
+type BackupNameFields struct {
+	Server string
+	Tag    string
+	// CreatedAt holds the date and time found in the name, in UTC
+	CreatedAt time.Time
+}
+
+// RenderBackupName fills the {server}, {date}, {time} and {tag} placeholders of a template.
+// Dates and times are written in UTC so names sort and parse the same everywhere.
+func RenderBackupName(template string, server Server, tag string, at time.Time) string {
+	at = at.UTC()
+	replacer := strings.NewReplacer(
+		"{server}", server.Attributes.Name,
+		"{date}", at.Format(BackupNameDateLayout),
+		"{time}", at.Format(BackupNameTimeLayout),
+		"{tag}", tag,
+	)
+
+	return strings.TrimSpace(replacer.Replace(template))
+}
+
+func backupNamePattern(template string) (*regexp.Regexp, error) {
+	var pattern strings.Builder
+	pattern.WriteString("^")
+
+	last := 0
+	for _, match := range backupNamePlaceholder.FindAllStringIndex(template, -1) {
+		pattern.WriteString(regexp.QuoteMeta(template[last:match[0]]))
+		pattern.WriteString(backupNamePlaceholders[template[match[0]:match[1]]])
+		last = match[1]
+	}
+	pattern.WriteString(regexp.QuoteMeta(template[last:]))
+	pattern.WriteString("$")
+
+	return regexp.Compile(pattern.String())
+}
+
+// ParseBackupName recognizes a backup name produced by RenderBackupName with the same template.
+func ParseBackupName(template string, name string) (BackupNameFields, bool) {
+	var fields BackupNameFields
+
+	pattern, err := backupNamePattern(strings.TrimSpace(template))
+	if err != nil {
+		return fields, false
+	}
+
+	match := pattern.FindStringSubmatch(name)
+	if match == nil {
+		// Names rendered with an empty trailing placeholder lose their trailing separator
+		match = pattern.FindStringSubmatch(name + " ")
+	}
+	if match == nil {
+		return fields, false
+	}
+
+	var date, clock string
+	for i, group := range pattern.SubexpNames() {
+		switch group {
+		case "server":
+			fields.Server = match[i]
+		case "tag":
+			fields.Tag = match[i]
+		case "date":
+			date = match[i]
+		case "time":
+			clock = match[i]
+		}
+	}
+
+	if date != "" {
+		layout, value := BackupNameDateLayout, date
+		if clock != "" {
+			layout, value = layout+" "+BackupNameTimeLayout, value+" "+clock
+		}
+
+		fields.CreatedAt, err = time.Parse(layout, value)
+		if err != nil {
+			return fields, false
+		}
+	}
+
+	return fields, true
+}
+
+// MatchBackupsByTemplate keeps the backups whose name was rendered from the template, which
+// lets pruning leave manually created backups alone.
+func MatchBackupsByTemplate(backups []Backup, template string) []Backup {
+	var matches []Backup
+	for _, backup := range backups {
+		if _, ok := ParseBackupName(template, backup.Attributes.Name); ok {
+			matches = append(matches, backup)
+		}
+	}
+	return matches
+}
@@ -0,0 +1,51 @@
+package pterodactyl
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRenderAndParseBackupName(t *testing.T) {
+	const template = "{server} {date} {time} {tag}"
+	server := decodeServer(t, `{"attributes": {"name": "survival world"}}`)
+	at := time.Date(2024, time.March, 9, 14, 30, 5, 0, time.FixedZone("CET", 3600))
+
+	for _, tag := range []string{"nightly", ""} {
+		name := RenderBackupName(template, server, tag, at)
+
+		fields, ok := ParseBackupName(template, name)
+		if !ok {
+			t.Fatalf("ParseBackupName(%q) did not match", name)
+		}
+		if fields.Server != "survival world" || fields.Tag != tag || !fields.CreatedAt.Equal(at) {
+			t.Errorf("ParseBackupName(%q) = %+v, want server %q, tag %q and %s", name, fields, "survival world", tag, at.UTC())
+		}
+	}
+}
+
+func TestParseBackupName(t *testing.T) {
+	tests := map[string]struct {
+		template string
+		name     string
+		ok       bool
+		tag      string
+	}{
+		"date only":         {template: "backup-{date}", name: "backup-2024-03-09", ok: true},
+		"literal dots":      {template: "{server}.{tag}", name: "lobby.pre-update", ok: true, tag: "pre-update"},
+		"manual backup":     {template: "{server} {date} {time}", name: "before the update", ok: false},
+		"invalid date":      {template: "backup-{date}", name: "backup-2024-13-45", ok: false},
+		"different literal": {template: "auto-{date}", name: "backup-2024-03-09", ok: false},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			fields, ok := ParseBackupName(test.template, test.name)
+			if ok != test.ok {
+				t.Fatalf("ParseBackupName(%q, %q) matched = %t, want %t", test.template, test.name, ok, test.ok)
+			}
+			if fields.Tag != test.tag {
+				t.Errorf("ParseBackupName(%q, %q) tag = %q, want %q", test.template, test.name, fields.Tag, test.tag)
+			}
+		})
+	}
+}
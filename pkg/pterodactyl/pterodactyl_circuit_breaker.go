@@ -0,0 +1,119 @@
+package pterodactyl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	CircuitClosed   string = "closed"
+	CircuitOpen     string = "open"
+	CircuitHalfOpen string = "half_open"
+)
+
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// CircuitBreaker fails calls fast once a panel answered with consecutive failures. After the
+// cooldown a single trial call is let through, its outcome closes or reopens the circuit.
+// Share one breaker per panel between goroutines through PterodactylServer.CircuitBreaker.
+type CircuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+
+	mutex       sync.Mutex
+	failures    int
+	openedUntil time.Time
+	trial       bool
+}
+
+func NewCircuitBreaker(threshold int, cooldown time.Duration) *CircuitBreaker {
+	if threshold < 1 {
+		threshold = 1
+	}
+	return &CircuitBreaker{threshold: threshold, cooldown: cooldown}
+}
+
+// State, Allow, RecordSuccess and RecordFailure let callers drive the breaker around their
+// own requests, they use the system clock. The SDK uses the clock of the PterodactylServer.
+func (breaker *CircuitBreaker) State() string {
+	return breaker.state(time.Now())
+}
+
+func (breaker *CircuitBreaker) Allow() error {
+	return breaker.allow(time.Now())
+}
+
+func (breaker *CircuitBreaker) RecordSuccess() {
+	breaker.mutex.Lock()
+	defer breaker.mutex.Unlock()
+
+	breaker.failures = 0
+	breaker.trial = false
+}
+
+func (breaker *CircuitBreaker) RecordFailure() {
+	breaker.recordFailure(time.Now())
+}
+
+func (breaker *CircuitBreaker) state(now time.Time) string {
+	breaker.mutex.Lock()
+	defer breaker.mutex.Unlock()
+
+	switch {
+	case breaker.failures < breaker.threshold:
+		return CircuitClosed
+	case now.Before(breaker.openedUntil):
+		return CircuitOpen
+	}
+	return CircuitHalfOpen
+}
+
+func (breaker *CircuitBreaker) allow(now time.Time) error {
+	breaker.mutex.Lock()
+	defer breaker.mutex.Unlock()
+
+	if breaker.failures < breaker.threshold {
+		return nil
+	}
+
+	if now.Before(breaker.openedUntil) || breaker.trial {
+		retryAt := breaker.openedUntil
+		if retryAt.Before(now) {
+			retryAt = now
+		}
+		return fmt.Errorf("%w after %d consecutive failures, retry after %s", ErrCircuitOpen, breaker.failures, retryAt.Format(time.RFC3339))
+	}
+
+	breaker.trial = true
+	return nil
+}
+
+func (breaker *CircuitBreaker) recordFailure(now time.Time) {
+	breaker.mutex.Lock()
+	defer breaker.mutex.Unlock()
+
+	breaker.failures++
+	breaker.trial = false
+	if breaker.failures >= breaker.threshold {
+		breaker.openedUntil = now.Add(breaker.cooldown)
+	}
+}
+
+// record counts only failures telling the panel is unavailable, client errors such as a
+// missing server say nothing about its health. Requests canceled by the SDK count as neither.
+func (breaker *CircuitBreaker) record(now time.Time, statusCode int, err error) {
+	switch {
+	case errors.Is(err, context.Canceled):
+		breaker.mutex.Lock()
+		breaker.trial = false
+		breaker.mutex.Unlock()
+	case err != nil, statusCode >= http.StatusInternalServerError:
+		breaker.recordFailure(now)
+	default:
+		breaker.RecordSuccess()
+	}
+}
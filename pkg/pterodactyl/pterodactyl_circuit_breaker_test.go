@@ -0,0 +1,149 @@
+package pterodactyl
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	clock := newFakeClock()
+	breaker := NewCircuitBreaker(2, time.Minute)
+
+	breaker.record(clock.Now(), http.StatusNotFound, nil)
+	breaker.record(clock.Now(), http.StatusBadGateway, nil)
+	if state := breaker.state(clock.Now()); state != CircuitClosed {
+		t.Fatalf("state() after one failure = %s, want %s", state, CircuitClosed)
+	}
+
+	breaker.record(clock.Now(), 0, errors.New("connection refused"))
+	if state := breaker.state(clock.Now()); state != CircuitOpen {
+		t.Fatalf("state() after two failures = %s, want %s", state, CircuitOpen)
+	}
+	if err := breaker.allow(clock.Now()); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("allow() while open error = %v, want %v", err, ErrCircuitOpen)
+	}
+
+	clock.Advance(time.Minute)
+	if state := breaker.state(clock.Now()); state != CircuitHalfOpen {
+		t.Fatalf("state() after the cooldown = %s, want %s", state, CircuitHalfOpen)
+	}
+	if err := breaker.allow(clock.Now()); err != nil {
+		t.Fatalf("allow() of the trial call error = %s", err)
+	}
+	if err := breaker.allow(clock.Now()); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("allow() during the trial call error = %v, want %v", err, ErrCircuitOpen)
+	}
+
+	breaker.record(clock.Now(), http.StatusServiceUnavailable, nil)
+	if state := breaker.state(clock.Now()); state != CircuitOpen {
+		t.Fatalf("state() after a failed trial = %s, want %s", state, CircuitOpen)
+	}
+
+	clock.Advance(time.Minute)
+	if err := breaker.allow(clock.Now()); err != nil {
+		t.Fatalf("allow() of the second trial call error = %s", err)
+	}
+	breaker.record(clock.Now(), http.StatusOK, nil)
+	if state := breaker.state(clock.Now()); state != CircuitClosed {
+		t.Errorf("state() after a successful trial = %s, want %s", state, CircuitClosed)
+	}
+}
+
+func TestCircuitBreakerGuardsDownloads(t *testing.T) {
+	var requests int32
+	storage := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer storage.Close()
+
+	clock := newFakeClock()
+	pterodactylServer := PterodactylServer{CircuitBreaker: NewCircuitBreaker(1, time.Minute), Clock: clock}
+	fetchUrl := func() (string, error) { return fmt.Sprintf("%s/backup.tar.gz", storage.URL), nil }
+	destination := t.TempDir() + "/backup.tar.gz"
+
+	_, err := downloadToFile(pterodactylServer, fetchUrl, destination, nil)
+	if !IsStatusCode(err, http.StatusBadGateway) {
+		t.Fatalf("downloadToFile() error = %v, want status code %d", err, http.StatusBadGateway)
+	}
+
+	_, err = downloadToFile(pterodactylServer, fetchUrl, destination, nil)
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("downloadToFile() with an open circuit error = %v, want %v", err, ErrCircuitOpen)
+	}
+	if atomic.LoadInt32(&requests) != 1 {
+		t.Errorf("storage received %d requests, want 1", requests)
+	}
+
+	// The cooldown follows the clock of the PterodactylServer rather than the system clock
+	clock.Advance(time.Minute)
+	_, err = downloadToFile(pterodactylServer, fetchUrl, destination, nil)
+	if !IsStatusCode(err, http.StatusBadGateway) {
+		t.Errorf("downloadToFile() after the cooldown error = %v, want status code %d", err, http.StatusBadGateway)
+	}
+}
+
+type closeRecorder struct {
+	io.Reader
+	closed bool
+}
+
+func (body *closeRecorder) Close() error {
+	body.closed = true
+	return nil
+}
+
+func TestCircuitBreakerClosesRejectedBodies(t *testing.T) {
+	breaker := NewCircuitBreaker(1, time.Minute)
+	clock := newFakeClock()
+	breaker.recordFailure(clock.Now())
+	pterodactylServer := PterodactylServer{CircuitBreaker: breaker, Clock: clock}
+
+	body := &closeRecorder{Reader: strings.NewReader("content")}
+	req, _ := http.NewRequest(http.MethodPost, "http://panel.invalid/upload", body)
+	_, err := pterodactylServer.doRequest(req)
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("doRequest() error = %v, want %v", err, ErrCircuitOpen)
+	}
+	if !body.closed {
+		t.Error("doRequest() left the body of the rejected request open")
+	}
+}
+
+func TestCircuitBreakerGuardsUploads(t *testing.T) {
+	var uploads int32
+	var panel *httptest.Server
+	panel = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/files/upload") {
+			fmt.Fprintf(w, `{"object": "signed_url", "attributes": {"url": "%s/upload/file"}}`, panel.URL)
+			return
+		}
+		atomic.AddInt32(&uploads, 1)
+	}))
+	defer panel.Close()
+
+	breaker := NewCircuitBreaker(1, time.Hour)
+	pterodactylServer := PterodactylServer{
+		Url:            panel.URL,
+		CircuitBreaker: breaker,
+		// Open the circuit between fetching the upload url and uploading
+		OnResponse: func(method string, url string, response ApiResponse) { breaker.RecordFailure() },
+	}
+
+	var server Server
+	server.Attributes.UUID = "c9b1d8e2-0000"
+	err := UploadServerFile(pterodactylServer, server, "/", "backup.tar.gz", strings.NewReader("content"))
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("UploadServerFile() error = %v, want %v", err, ErrCircuitOpen)
+	}
+	if atomic.LoadInt32(&uploads) != 0 {
+		t.Errorf("UploadServerFile() sent %d uploads through an open circuit, want 0", uploads)
+	}
+}
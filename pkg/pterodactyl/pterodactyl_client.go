@@ -1,6 +1,7 @@
 package pterodactyl
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -15,17 +16,57 @@ import (
 	log "github.com/sirupsen/logrus"
 )
 
-var (
-	WaitForBackupSeconds int64 = 5
-)
-
 const (
-	ApiEndpointBase    string = "api"
-	ApiEndpointServers string = "client"
-	ApiEndpointServer  string = "client/servers"
-	ApiEndpointBackups string = "backups"
+	ApiEndpointBase        string = "api"
+	ApiEndpointServers     string = "client"
+	ApiEndpointServer      string = "client/servers"
+	ApiEndpointBackups     string = "backups"
+	ApiEndpointNodes       string = "application/nodes"
+	ApiEndpointNests       string = "application/nests"
+	ApiEndpointEggs        string = "eggs"
+	ApiEndpointAllocations string = "allocations"
+
+	ApiEndpointApplicationServers string = "application/servers"
+
+	ApiMaxPerPage int = 100
 )
 
+// defaultHTTPClient is used instead of http.DefaultClient so other packages changing the
+// latter do not affect the SDK.
+var defaultHTTPClient = &http.Client{}
+
+func (pterodactylServer PterodactylServer) httpClient() *http.Client {
+	if pterodactylServer.HTTPClient == nil {
+		return defaultHTTPClient
+	}
+	return pterodactylServer.HTTPClient
+}
+
+// doRequest sends a request through the circuit breaker of the panel, when there is one.
+// Like http.Client.Do, it closes the request body even when the request is not sent.
+func (pterodactylServer PterodactylServer) doRequest(req *http.Request) (*http.Response, error) {
+	breaker := pterodactylServer.CircuitBreaker
+	if breaker != nil {
+		err := breaker.allow(pterodactylServer.clock().Now())
+		if err != nil {
+			if req.Body != nil {
+				req.Body.Close()
+			}
+			return nil, err
+		}
+	}
+
+	res, err := pterodactylServer.httpClient().Do(req)
+	if breaker != nil {
+		var statusCode int
+		if res != nil {
+			statusCode = res.StatusCode
+		}
+		breaker.record(pterodactylServer.clock().Now(), statusCode, err)
+	}
+	return res, err
+}
+
 func buildApiUrl(pterodactylServer PterodactylServer, endpoint string, subPaths []string) string {
 	url := fmt.Sprintf("%s/%s/%s", pterodactylServer.Url, ApiEndpointBase, endpoint)
 
@@ -36,168 +77,286 @@ func buildApiUrl(pterodactylServer PterodactylServer, endpoint string, subPaths
 }
 
 func callApi[T any](apiObject *T, pterodactylServer PterodactylServer, method string, endpoint string, subPaths []string, data map[string]string) error {
+	return callApiWithQuery(apiObject, pterodactylServer, method, endpoint, subPaths, nil, data)
+}
+
+func callApiWithQuery[T any](apiObject *T, pterodactylServer PterodactylServer, method string, endpoint string, subPaths []string, query url.Values, data map[string]string) error {
+	_, err := requestApi(apiObject, pterodactylServer, method, endpoint, subPaths, query, data)
+	return err
+}
+
+// requestApi sends data form encoded when it is a map[string]string and as JSON otherwise,
+// for endpoints that need real booleans or nested values.
+func requestApi[T any](apiObject *T, pterodactylServer PterodactylServer, method string, endpoint string, subPaths []string, query url.Values, data any) (*ApiResponse, error) {
 	apiUrl := buildApiUrl(pterodactylServer, endpoint, subPaths)
+	if len(query) > 0 {
+		apiUrl = fmt.Sprintf("%s?%s", apiUrl, query.Encode())
+	}
 
-	dataToSend := url.Values{}
+	send := func() (*ApiResponse, []byte, error) {
+		return sendApiRequest(pterodactylServer, method, apiUrl, data)
+	}
 
-	for k, v := range data {
-		dataToSend.Set(k, v)
+	var response *ApiResponse
+	var responseBody []byte
+	var err error
+	if method == http.MethodGet && pterodactylServer.Coalescer != nil {
+		response, responseBody, err = pterodactylServer.Coalescer.do(fmt.Sprintf("%s|%s", pterodactylServer.ApiKey, apiUrl), send)
+	} else {
+		response, responseBody, err = send()
+	}
+	// Coalesced callers share the response of a single request but each of them is notified
+	if response != nil && pterodactylServer.OnResponse != nil {
+		pterodactylServer.OnResponse(method, apiUrl, *response)
+	}
+	if err != nil {
+		return response, err
 	}
 
-	req, _ := http.NewRequest(method, apiUrl, strings.NewReader(dataToSend.Encode()))
+	err = decodeApiResponse(apiObject, response, responseBody)
+	if err == nil && pterodactylServer.PanelFlavor() == PanelFlavorWisp {
+		applyWispFallbacks(apiObject, responseBody)
+	}
+	return response, err
+}
+
+func sendApiRequest(pterodactylServer PterodactylServer, method string, apiUrl string, data any) (*ApiResponse, []byte, error) {
+	var body io.Reader
+	var contentType string
+
+	switch data := data.(type) {
+	case nil:
+	case map[string]string:
+		dataToSend := url.Values{}
+
+		for k, v := range data {
+			dataToSend.Set(k, v)
+		}
+
+		if len(dataToSend) > 0 {
+			body = strings.NewReader(dataToSend.Encode())
+			contentType = "application/x-www-form-urlencoded"
+		}
+	default:
+		encoded, err := json.Marshal(data)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		body = bytes.NewReader(encoded)
+		contentType = "application/json"
+	}
+
+	req, _ := http.NewRequest(method, apiUrl, body)
 	req.Header.Add("Accept", "application/json")
 	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", pterodactylServer.ApiKey))
-	res, err := http.DefaultClient.Do(req)
+	if contentType != "" {
+		req.Header.Add("Content-Type", contentType)
+	}
+
+	res, err := pterodactylServer.doRequest(req)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 
 	defer res.Body.Close()
-	body, _ := ioutil.ReadAll(res.Body)
+	responseBody, _ := ioutil.ReadAll(res.Body)
 
-	if res.StatusCode != http.StatusOK {
-		var apiErrors ApiErrors
+	return &ApiResponse{StatusCode: res.StatusCode, Header: res.Header}, responseBody, nil
+}
 
-		err = json.Unmarshal(body, &apiErrors)
-		if err != nil {
-			return err
+func decodeApiResponse[T any](apiObject *T, response *ApiResponse, responseBody []byte) error {
+	switch response.StatusCode {
+	case http.StatusOK, http.StatusCreated:
+		if len(responseBody) == 0 {
+			return nil
 		}
-
-		return fmt.Errorf("api call failed with errors: %s", apiErrors)
+		return decodeLenient(responseBody, apiObject)
+	case http.StatusAccepted, http.StatusNoContent:
+		// Power, command, reinstall and delete endpoints answer without a body
+		return nil
 	}
 
-	err = json.Unmarshal(body, &apiObject)
-	return err
+	var apiErrors ApiErrors
+
+	// Proxies and load balancers may answer with a non JSON body, keep the status code regardless
+	_ = json.Unmarshal(responseBody, &apiErrors)
+	return &ApiRequestError{StatusCode: response.StatusCode, Header: response.Header, Errors: apiErrors.Errors, Body: responseBody}
 }
 
 func GetServers(pterodactylServer PterodactylServer) ([]Server, error) {
-	var servers Servers
+	var servers ListResponse[Server]
 	err := callApi(&servers, pterodactylServer, http.MethodGet, ApiEndpointServers, nil, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	if servers.Servers == nil {
+	if servers.Data == nil {
 		return nil, errors.New("no servers returned")
 	}
 
-	return servers.Servers, nil
+	return servers.Data, nil
 }
 
 func GetServer(pterodactylServer PterodactylServer, serverId string) (Server, error) {
-	var server Server
+	var server ItemResponse[Server]
 	err := callApi(&server, pterodactylServer, http.MethodGet, ApiEndpointServer, []string{serverId}, nil)
 	if err != nil {
-		return server, err
+		return server.Data, err
 	}
 
-	return server, nil
+	return server.Data, nil
 }
 
 func GetServerBackups(pterodactylServer PterodactylServer, server Server) ([]Backup, error) {
-	var backups Backups
+	if err := requireFeature(pterodactylServer, FeatureBackups); err != nil {
+		return nil, err
+	}
+
+	var backups ListResponse[Backup]
 	err := callApi(&backups, pterodactylServer, http.MethodGet, ApiEndpointServer, []string{server.Attributes.UUID, ApiEndpointBackups}, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	if backups.Backups == nil {
+	if backups.Data == nil {
 		return nil, errors.New("no backups returned")
 	}
 
-	return backups.Backups, nil
+	return backups.Data, nil
 }
 
 func GetServerBackup(pterodactylServer PterodactylServer, server Server, backupId string) (Backup, error) {
-	var backup Backup
+	if err := requireFeature(pterodactylServer, FeatureBackups); err != nil {
+		return Backup{}, err
+	}
+
+	var backup ItemResponse[Backup]
 	err := callApi(&backup, pterodactylServer, http.MethodGet, ApiEndpointServer, []string{server.Attributes.UUID, ApiEndpointBackups, backupId}, nil)
 	if err != nil {
-		return backup, err
+		return backup.Data, err
 	}
 
-	return backup, nil
+	return backup.Data, nil
 }
 
 func DeleteServerBackup(pterodactylServer PterodactylServer, server Server, backupId string) (Backup, error) {
-	var backup Backup
+	if err := requireFeature(pterodactylServer, FeatureBackups); err != nil {
+		return Backup{}, err
+	}
+	if err := checkDestructiveOperation(pterodactylServer, DestructiveOperation{Kind: OperationDeleteBackup, ServerName: server.Attributes.Name, ServerUUID: server.Attributes.UUID, Target: backupId}); err != nil {
+		return Backup{}, err
+	}
+
+	var backup ItemResponse[Backup]
 	err := callApi(&backup, pterodactylServer, string(http.MethodDelete), ApiEndpointServer, []string{server.Attributes.UUID, ApiEndpointBackups, backupId}, nil)
 	if err != nil {
-		return backup, err
+		return backup.Data, err
 	}
 
-	return backup, nil
+	return backup.Data, nil
 }
 
-func DownloadServerBackup(pterodactylServer PterodactylServer, server Server, backupId string, destination string) (*os.File, error) {
-	var backupUrl BackupUrl
-	var out *os.File
-	err := callApi(&backupUrl, pterodactylServer, http.MethodGet, ApiEndpointServer, []string{server.Attributes.UUID, ApiEndpointBackups, backupId, "download"}, nil)
-	if err != nil {
-		return nil, err
+func RestoreServerBackup(pterodactylServer PterodactylServer, server Server, backupId string, truncate bool) error {
+	if err := requireFeature(pterodactylServer, FeatureBackups); err != nil {
+		return err
+	}
+	if truncate {
+		if err := checkDestructiveOperation(pterodactylServer, DestructiveOperation{Kind: OperationRestoreBackupTruncate, ServerName: server.Attributes.Name, ServerUUID: server.Attributes.UUID, Target: backupId}); err != nil {
+			return err
+		}
 	}
 
-	log.Trace(fmt.Sprintf("DownloadServerBackup -> Attempting to download: '%s'", backupUrl.Attributes.URL))
+	// The panel forwards truncate to the daemon as is, so it must be a JSON boolean
+	var response struct{}
+	_, err := requestApi(&response, pterodactylServer, http.MethodPost, ApiEndpointServer, []string{server.Attributes.UUID, ApiEndpointBackups, backupId, "restore"}, nil, map[string]bool{"truncate": truncate})
+	return err
+}
 
-	req, _ := http.NewRequest(http.MethodGet, backupUrl.Attributes.URL, nil)
-	req.Header.Add("Accept", "application/json")
-	res, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return nil, err
+func GetServerBackupDownloadUrl(pterodactylServer PterodactylServer, server Server, backupId string) (string, error) {
+	if err := requireFeature(pterodactylServer, FeatureBackups); err != nil {
+		return "", err
 	}
 
-	defer res.Body.Close()
-	log.Trace(fmt.Sprintf("DownloadServerBackup -> Status Code: '%d'", res.StatusCode))
+	var backupUrl ItemResponse[BackupUrl]
+	err := callApi(&backupUrl, pterodactylServer, http.MethodGet, ApiEndpointServer, []string{server.Attributes.UUID, ApiEndpointBackups, backupId, "download"}, nil)
+	if err != nil {
+		return "", err
+	}
 
-	if res.StatusCode == http.StatusOK {
-		out, err = os.Create(destination)
-		log.Trace(fmt.Sprintf("DownloadServerBackup -> Creating file: '%s'", destination))
-		if err != nil {
-			return nil, err
-		}
-		defer out.Close()
+	return backupUrl.Data.Attributes.URL, nil
+}
 
-		log.Trace(fmt.Sprintf("DownloadServerBackup -> Copying repsonse body to file: '%s'", destination))
-		_, err = io.Copy(out, res.Body)
-		if err != nil {
-			return nil, err
-		}
-	} else {
-		return nil, fmt.Errorf("download failed with status code %d", res.StatusCode)
+// DownloadServerBackup saves a backup to destination, pass WithChecksum with the hash of the
+// backup to have the download verified.
+func DownloadServerBackup(pterodactylServer PterodactylServer, server Server, backupId string, destination string, options ...DownloadOption) (*os.File, error) {
+	fetchUrl := func() (string, error) {
+		return GetServerBackupDownloadUrl(pterodactylServer, server, backupId)
 	}
 
-	return out, nil
+	return downloadToFile(pterodactylServer, fetchUrl, destination, options)
 }
 
 func BackupServer(pterodactylServer PterodactylServer, server Server) (Backup, error) {
-	var backup Backup
-
-	err := callApi(&backup, pterodactylServer, http.MethodPost, fmt.Sprintf("%s/%s/%s", ApiEndpointServer, server.Attributes.UUID, ApiEndpointBackups), nil, nil)
-	if err != nil {
-		return backup, err
+	var name string
+	if pterodactylServer.BackupNameTemplate != "" {
+		name = RenderBackupName(pterodactylServer.BackupNameTemplate, server, "", pterodactylServer.clock().Now())
 	}
 
-	return backup, nil
+	return BackupServerWithName(pterodactylServer, server, name)
 }
 
-func BackupServerWithWait(pterodactylServer PterodactylServer, server Server) (*Backup, error) {
-	backup, err := BackupServer(pterodactylServer, server)
+func BackupServerWithName(pterodactylServer PterodactylServer, server Server, name string) (Backup, error) {
+	if err := requireFeature(pterodactylServer, FeatureBackups); err != nil {
+		return Backup{}, err
+	}
+
+	var backup ItemResponse[Backup]
+	var data map[string]string
+	if name != "" {
+		data = map[string]string{"name": name}
+	}
+
+	err := callApi(&backup, pterodactylServer, http.MethodPost, fmt.Sprintf("%s/%s/%s", ApiEndpointServer, server.Attributes.UUID, ApiEndpointBackups), nil, data)
 	if err != nil {
-		return nil, err
+		return backup.Data, err
 	}
 
+	return backup.Data, nil
+}
+
+func WaitForBackup(pterodactylServer PterodactylServer, server Server, backupId string, optionFuncs ...WaitOption) (*Backup, error) {
+	options := newWaitOptions(DefaultBackupPollInterval, optionFuncs)
+	clock := pterodactylServer.clock()
+	start := clock.Now()
+
 	// Wait until backup is completed on the pterodactylServer side
 	for {
-		backup, err = GetServerBackup(pterodactylServer, server, backup.Attributes.UUID)
+		backup, err := GetServerBackup(pterodactylServer, server, backupId)
 		if err != nil {
 			return nil, err
 		}
 
 		if !time.Time.IsZero(backup.Attributes.CompletedAt) {
-			time.Sleep(time.Duration(WaitForBackupSeconds) * time.Second)
-			log.Debugf("Waiting for backup...")
-			break
+			return &backup, nil
+		}
+
+		if options.expired(clock, start) {
+			return nil, fmt.Errorf("%w '%s' to complete backup '%s'", ErrWaitTimeout, server.Attributes.Name, backupId)
+		}
+
+		log.Debugf("Waiting for backup...")
+		if !options.sleep(clock) {
+			return nil, ErrWaitStopped
 		}
 	}
+}
+
+func BackupServerWithWait(pterodactylServer PterodactylServer, server Server, options ...WaitOption) (*Backup, error) {
+	backup, err := BackupServer(pterodactylServer, server)
+	if err != nil {
+		return nil, err
+	}
 
-	return &backup, nil
+	return WaitForBackup(pterodactylServer, server, backup.Attributes.UUID, options...)
 }
@@ -0,0 +1,32 @@
+package pterodactyl
+
+import (
+	"time"
+)
+
+// Clock lets tests drive the wait helpers and retry backoffs without real delays.
+type Clock interface {
+	Now() time.Time
+	After(duration time.Duration) <-chan time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) After(duration time.Duration) <-chan time.Time {
+	return time.After(duration)
+}
+
+func (pterodactylServer PterodactylServer) clock() Clock {
+	if pterodactylServer.Clock == nil {
+		return systemClock{}
+	}
+	return pterodactylServer.Clock
+}
+
+func (pterodactylServer PterodactylServer) sleep(duration time.Duration) {
+	<-pterodactylServer.clock().After(duration)
+}
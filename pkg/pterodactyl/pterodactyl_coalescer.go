@@ -0,0 +1,58 @@
+package pterodactyl
+
+import (
+	"bytes"
+	"sync"
+)
+
+// RequestCoalescer sends identical GET requests that are in flight at the same time only once
+// and hands the response to every caller, PterodactylServer.OnResponse is called for each of
+// them. Share one between goroutines through PterodactylServer.Coalescer.
+type RequestCoalescer struct {
+	mutex sync.Mutex
+	calls map[string]*coalescedCall
+}
+
+type coalescedCall struct {
+	done     chan struct{}
+	response *ApiResponse
+	body     []byte
+	err      error
+}
+
+func NewRequestCoalescer() *RequestCoalescer {
+	return &RequestCoalescer{calls: map[string]*coalescedCall{}}
+}
+
+func (coalescer *RequestCoalescer) do(key string, send func() (*ApiResponse, []byte, error)) (*ApiResponse, []byte, error) {
+	coalescer.mutex.Lock()
+	if coalescer.calls == nil {
+		coalescer.calls = map[string]*coalescedCall{}
+	}
+	if call, ok := coalescer.calls[key]; ok {
+		coalescer.mutex.Unlock()
+
+		<-call.done
+		if call.response == nil {
+			return nil, nil, call.err
+		}
+		// Every caller gets its own copy, the body and headers end up in the returned errors
+		response := *call.response
+		response.Header = call.response.Header.Clone()
+		return &response, bytes.Clone(call.body), call.err
+	}
+
+	call := &coalescedCall{done: make(chan struct{})}
+	coalescer.calls[key] = call
+	coalescer.mutex.Unlock()
+
+	defer func() {
+		coalescer.mutex.Lock()
+		delete(coalescer.calls, key)
+		coalescer.mutex.Unlock()
+		close(call.done)
+	}()
+
+	call.response, call.body, call.err = send()
+	return call.response, call.body, call.err
+}
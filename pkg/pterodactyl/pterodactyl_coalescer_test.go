@@ -0,0 +1,68 @@
+package pterodactyl
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestRequestCoalescerCopiesResponse(t *testing.T) {
+	coalescer := NewRequestCoalescer()
+
+	// A call already in flight, the next caller waits for it rather than sending again
+	call := &coalescedCall{
+		done:     make(chan struct{}),
+		response: &ApiResponse{StatusCode: http.StatusOK, Header: http.Header{"X-Ratelimit-Remaining": {"59"}}},
+		body:     []byte(`{"data": []}`),
+	}
+	close(call.done)
+	coalescer.calls["key"] = call
+
+	response, body, err := coalescer.do("key", func() (*ApiResponse, []byte, error) {
+		t.Error("do() sent a request although an identical one was in flight")
+		return nil, nil, nil
+	})
+	if err != nil {
+		t.Fatalf("do() error = %s", err)
+	}
+
+	response.Header.Set("X-Ratelimit-Remaining", "0")
+	body[0] = '['
+	if remaining := call.response.Header.Get("X-Ratelimit-Remaining"); remaining != "59" {
+		t.Errorf("modifying the returned headers changed the shared response to %q", remaining)
+	}
+	if call.body[0] != '{' {
+		t.Error("modifying the returned body changed the shared response")
+	}
+}
+
+func TestRequestCoalescerNotifiesEveryCaller(t *testing.T) {
+	var responses []string
+	pterodactylServer := PterodactylServer{
+		ApiKey:    "key",
+		Url:       "http://panel.invalid",
+		Coalescer: NewRequestCoalescer(),
+		OnResponse: func(method string, url string, response ApiResponse) {
+			responses = append(responses, fmt.Sprintf("%s %s %d", method, url, response.StatusCode))
+		},
+	}
+
+	// The caller joins a request already in flight, it is never sent to the unreachable panel
+	apiUrl := buildApiUrl(pterodactylServer, ApiEndpointServer, []string{"c9b1d8e2"})
+	call := &coalescedCall{
+		done:     make(chan struct{}),
+		response: &ApiResponse{StatusCode: http.StatusOK, Header: http.Header{}},
+		body:     []byte(`{"attributes": {"uuid": "c9b1d8e2-0000"}}`),
+	}
+	close(call.done)
+	pterodactylServer.Coalescer.calls[fmt.Sprintf("%s|%s", pterodactylServer.ApiKey, apiUrl)] = call
+
+	server, err := GetServer(pterodactylServer, "c9b1d8e2")
+	if err != nil || server.Attributes.UUID != "c9b1d8e2-0000" {
+		t.Fatalf("GetServer() = %+v, %v, want the shared response", server.Attributes, err)
+	}
+	if expected := []string{fmt.Sprintf("GET %s 200", apiUrl)}; !reflect.DeepEqual(responses, expected) {
+		t.Errorf("OnResponse() calls = %v, want %v", responses, expected)
+	}
+}
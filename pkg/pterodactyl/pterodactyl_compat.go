@@ -0,0 +1,109 @@
+package pterodactyl
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+)
+
+const (
+	PanelFlavorPterodactyl string = "pterodactyl"
+	PanelFlavorWisp        string = "wisp"
+	PanelFlavorJexactyl    string = "jexactyl"
+)
+
+// Do is the escape hatch for endpoints the SDK does not wrap, such as panel fork extras.
+// The endpoint is relative to the api base, e.g. "client/servers", and the response body
+// is decoded into apiObject. A map[string]string data is form encoded, anything else is sent
+// as JSON. The response metadata is returned for failed calls as well.
+func Do[T any](apiObject *T, pterodactylServer PterodactylServer, method string, endpoint string, subPaths []string, query url.Values, data any) (*ApiResponse, error) {
+	return requestApi(apiObject, pterodactylServer, method, endpoint, subPaths, query, data)
+}
+
+func (pterodactylServer PterodactylServer) PanelFlavor() string {
+	if pterodactylServer.Flavor == "" {
+		return PanelFlavorPterodactyl
+	}
+	return pterodactylServer.Flavor
+}
+
+// DetectPanelFlavor lists one server through the client API and looks at the attributes the
+// forks add or rename: WISP reports uuid_short instead of identifier and Jexactyl adds the
+// renewal attributes. The key needs access to at least one server, set
+// PterodactylServer.Flavor explicitly otherwise.
+func DetectPanelFlavor(pterodactylServer PterodactylServer) (string, error) {
+	var servers struct {
+		Data []struct {
+			Attributes map[string]json.RawMessage `json:"attributes"`
+		} `json:"data"`
+	}
+	err := callApiWithQuery(&servers, pterodactylServer, http.MethodGet, ApiEndpointServers, nil, url.Values{"per_page": {"1"}}, nil)
+	if err != nil {
+		return "", err
+	}
+	if len(servers.Data) == 0 {
+		return "", errors.New("no servers returned to detect the panel flavor from")
+	}
+
+	attributes := servers.Data[0].Attributes
+	if _, ok := attributes["uuid_short"]; ok {
+		return PanelFlavorWisp, nil
+	}
+	if _, ok := attributes["renewal"]; ok {
+		return PanelFlavorJexactyl, nil
+	}
+	return PanelFlavorPterodactyl, nil
+}
+
+type wispServerAttributes struct {
+	UUID      string `json:"uuid"`
+	UUIDShort string `json:"uuid_short"`
+	Suspended *bool  `json:"suspended"`
+}
+
+// applyWispFallbacks fills the attributes WISP names differently so code written against the
+// Pterodactyl models keeps working on both panels.
+func applyWispFallbacks(apiObject any, responseBody []byte) {
+	var wisp struct {
+		Attributes wispServerAttributes `json:"attributes"`
+	}
+
+	switch apiObject := apiObject.(type) {
+	case *Server:
+		if decodeLenient(responseBody, &wisp) == nil {
+			wisp.Attributes.apply(apiObject)
+		}
+	case *ItemResponse[Server]:
+		if decodeLenient(responseBody, &wisp) == nil {
+			wisp.Attributes.apply(&apiObject.Data)
+		}
+	case *ListResponse[Server]:
+		var wispList struct {
+			Data []struct {
+				Attributes wispServerAttributes `json:"attributes"`
+			} `json:"data"`
+		}
+		_ = decodeLenient(responseBody, &wispList)
+
+		// Malformed records are skipped from the list so match them by uuid rather than index
+		byUUID := make(map[string]wispServerAttributes, len(wispList.Data))
+		for _, record := range wispList.Data {
+			byUUID[record.Attributes.UUID] = record.Attributes
+		}
+		for i := range apiObject.Data {
+			if attributes, ok := byUUID[apiObject.Data[i].Attributes.UUID]; ok {
+				attributes.apply(&apiObject.Data[i])
+			}
+		}
+	}
+}
+
+func (wisp wispServerAttributes) apply(server *Server) {
+	if server.Attributes.Identifier == "" {
+		server.Attributes.Identifier = wisp.UUIDShort
+	}
+	if wisp.Suspended != nil && *wisp.Suspended {
+		server.Attributes.IsSuspended = true
+	}
+}
@@ -0,0 +1,67 @@
+package pterodactyl
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func panelServing(t *testing.T, body string) PterodactylServer {
+	t.Helper()
+
+	panel := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, body)
+	}))
+	t.Cleanup(panel.Close)
+
+	return PterodactylServer{Url: panel.URL}
+}
+
+func TestDetectPanelFlavor(t *testing.T) {
+	tests := map[string]string{
+		PanelFlavorPterodactyl: `{"data": [{"attributes": {"identifier": "c9b1d8e2", "uuid": "c9b1d8e2-0000"}}]}`,
+		PanelFlavorWisp:        `{"data": [{"attributes": {"uuid_short": "c9b1d8e2", "uuid": "c9b1d8e2-0000"}}]}`,
+		PanelFlavorJexactyl:    `{"data": [{"attributes": {"identifier": "c9b1d8e2", "renewable": true, "renewal": 7}}]}`,
+	}
+
+	for expected, body := range tests {
+		t.Run(expected, func(t *testing.T) {
+			flavor, err := DetectPanelFlavor(panelServing(t, body))
+			if err != nil {
+				t.Fatalf("DetectPanelFlavor() error = %s", err)
+			}
+			if flavor != expected {
+				t.Errorf("DetectPanelFlavor() = %q, want %q", flavor, expected)
+			}
+		})
+	}
+
+	_, err := DetectPanelFlavor(panelServing(t, `{"data": []}`))
+	if err == nil {
+		t.Error("DetectPanelFlavor() without servers error = nil, want an error")
+	}
+}
+
+func TestWispFallbacks(t *testing.T) {
+	body := `{"attributes": {"uuid": "c9b1d8e2-0000", "uuid_short": "c9b1d8e2", "suspended": 1}}`
+
+	pterodactylServer := panelServing(t, body)
+	server, err := GetServer(pterodactylServer, "c9b1d8e2")
+	if err != nil {
+		t.Fatalf("GetServer() error = %s", err)
+	}
+	if server.Attributes.Identifier != "" || server.Attributes.IsSuspended {
+		t.Errorf("GetServer() applied the WISP fallbacks on a Pterodactyl panel: %+v", server.Attributes)
+	}
+
+	pterodactylServer.Flavor = PanelFlavorWisp
+	server, err = GetServer(pterodactylServer, "c9b1d8e2")
+	if err != nil {
+		t.Fatalf("GetServer() error = %s", err)
+	}
+	if server.Attributes.Identifier != "c9b1d8e2" || !server.Attributes.IsSuspended {
+		t.Errorf("GetServer() identifier = %q, suspended = %t, want %q and true", server.Attributes.Identifier, server.Attributes.IsSuspended, "c9b1d8e2")
+	}
+}
@@ -0,0 +1,117 @@
+package pterodactyl
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+type ChangeType string
+
+const (
+	ChangeAdded    ChangeType = "added"
+	ChangeRemoved  ChangeType = "removed"
+	ChangeModified ChangeType = "modified"
+)
+
+type ServerChange struct {
+	Field  string     `json:"field"`
+	Type   ChangeType `json:"type"`
+	Before any        `json:"before,omitempty"`
+	After  any        `json:"after,omitempty"`
+}
+
+type ServerChangeSet struct {
+	ServerUUID string         `json:"server_uuid"`
+	Changes    []ServerChange `json:"changes"`
+}
+
+func (changeSet ServerChangeSet) IsEmpty() bool {
+	return len(changeSet.Changes) == 0
+}
+
+func (changeSet *ServerChangeSet) compare(field string, before any, after any) {
+	if reflect.DeepEqual(before, after) {
+		return
+	}
+
+	changeSet.Changes = append(changeSet.Changes, ServerChange{Field: field, Type: ChangeModified, Before: before, After: after})
+}
+
+func (changeSet *ServerChangeSet) compareMaps(prefix string, before map[string]any, after map[string]any) {
+	var keys []string
+	for key := range before {
+		keys = append(keys, key)
+	}
+	for key := range after {
+		if _, ok := before[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		field := fmt.Sprintf("%s.%s", prefix, key)
+		beforeValue, inBefore := before[key]
+		afterValue, inAfter := after[key]
+
+		switch {
+		case !inBefore:
+			changeSet.Changes = append(changeSet.Changes, ServerChange{Field: field, Type: ChangeAdded, After: afterValue})
+		case !inAfter:
+			changeSet.Changes = append(changeSet.Changes, ServerChange{Field: field, Type: ChangeRemoved, Before: beforeValue})
+		default:
+			changeSet.compare(field, beforeValue, afterValue)
+		}
+	}
+}
+
+func limitsMap(limits ServerLimits) map[string]any {
+	return map[string]any{
+		"memory":       limits.Memory,
+		"swap":         limits.Swap,
+		"disk":         limits.Disk,
+		"io":           limits.Io,
+		"cpu":          limits.CPU,
+		"threads":      limits.Threads,
+		"oom_disabled": limits.OomDisabled,
+	}
+}
+
+func variablesMap(server Server) map[string]any {
+	variables := map[string]any{}
+	for _, variable := range server.Attributes.Relationships.Variables.Data {
+		variables[variable.Attributes.EnvVariable] = variable.Attributes.ServerValue
+	}
+	return variables
+}
+
+func allocationsMap(server Server) map[string]any {
+	allocations := map[string]any{}
+	for _, allocation := range server.Attributes.Relationships.Allocations.Data {
+		key := fmt.Sprintf("%s:%d", allocation.Attributes.IP, allocation.Attributes.Port)
+		allocations[key] = allocation.Attributes.IsDefault
+	}
+	return allocations
+}
+
+// Diff compares two snapshots of the same server and lists what changed between them.
+// Allocations are keyed by ip:port and their value records whether they are the default.
+func Diff(before Server, after Server) ServerChangeSet {
+	changeSet := ServerChangeSet{ServerUUID: after.Attributes.UUID}
+	if changeSet.ServerUUID == "" {
+		changeSet.ServerUUID = before.Attributes.UUID
+	}
+
+	changeSet.compare("name", before.Attributes.Name, after.Attributes.Name)
+	changeSet.compare("description", before.Attributes.Description, after.Attributes.Description)
+	changeSet.compare("docker_image", before.Attributes.DockerImage, after.Attributes.DockerImage)
+	changeSet.compare("invocation", before.Attributes.Invocation, after.Attributes.Invocation)
+	changeSet.compare("node", before.Attributes.Node, after.Attributes.Node)
+	changeSet.compareMaps("limits", limitsMap(before.Attributes.Limits), limitsMap(after.Attributes.Limits))
+	changeSet.compare("feature_limits", before.Attributes.FeatureLimits, after.Attributes.FeatureLimits)
+	changeSet.compareMaps("variables", variablesMap(before), variablesMap(after))
+	changeSet.compareMaps("allocations", allocationsMap(before), allocationsMap(after))
+
+	return changeSet
+}
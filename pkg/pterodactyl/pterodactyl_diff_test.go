@@ -0,0 +1,61 @@
+package pterodactyl
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func decodeServer(t *testing.T, data string) Server {
+	t.Helper()
+
+	var server Server
+	err := json.Unmarshal([]byte(data), &server)
+	if err != nil {
+		t.Fatalf("decoding server: %s", err)
+	}
+	return server
+}
+
+func TestDiff(t *testing.T) {
+	before := decodeServer(t, `{"attributes": {
+		"uuid": "c9b1d8e2-0000-0000-0000-000000000000", "name": "survival", "docker_image": "java:17",
+		"limits": {"memory": 2048, "disk": 10000},
+		"relationships": {
+			"allocations": {"data": [{"attributes": {"ip": "10.0.0.1", "port": 25565, "is_default": true}}]},
+			"variables": {"data": [{"attributes": {"env_variable": "VERSION", "server_value": "1.20"}}]}
+		}}}`)
+	after := decodeServer(t, `{"attributes": {
+		"uuid": "c9b1d8e2-0000-0000-0000-000000000000", "name": "survival", "docker_image": "java:21",
+		"limits": {"memory": 4096, "disk": 10000},
+		"relationships": {
+			"allocations": {"data": [
+				{"attributes": {"ip": "10.0.0.1", "port": 25565, "is_default": true}},
+				{"attributes": {"ip": "10.0.0.1", "port": 25566, "is_default": false}}
+			]},
+			"variables": {"data": []}
+		}}}`)
+
+	changeSet := Diff(before, after)
+
+	expected := []ServerChange{
+		{Field: "docker_image", Type: ChangeModified, Before: "java:17", After: "java:21"},
+		{Field: "limits.memory", Type: ChangeModified, Before: 2048, After: 4096},
+		{Field: "variables.VERSION", Type: ChangeRemoved, Before: "1.20"},
+		{Field: "allocations.10.0.0.1:25566", Type: ChangeAdded, After: false},
+	}
+	if !reflect.DeepEqual(changeSet.Changes, expected) {
+		t.Errorf("Diff() changes = %+v, want %+v", changeSet.Changes, expected)
+	}
+	if changeSet.ServerUUID != before.Attributes.UUID {
+		t.Errorf("Diff() server uuid = %q, want %q", changeSet.ServerUUID, before.Attributes.UUID)
+	}
+}
+
+func TestDiffIdenticalSnapshots(t *testing.T) {
+	server := decodeServer(t, `{"attributes": {"uuid": "c9b1d8e2-0000-0000-0000-000000000000", "name": "survival"}}`)
+
+	if changeSet := Diff(server, server); !changeSet.IsEmpty() {
+		t.Errorf("Diff() of identical snapshots = %+v, want no changes", changeSet.Changes)
+	}
+}
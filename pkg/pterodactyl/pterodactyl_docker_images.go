@@ -0,0 +1,80 @@
+package pterodactyl
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+)
+
+// DockerImageMap holds the images offered by an egg keyed by their label. Panels released
+// before labels were introduced send a plain list, each image is then its own label.
+type DockerImageMap map[string]string
+
+func (images *DockerImageMap) UnmarshalJSON(data []byte) error {
+	var labelled map[string]string
+	err := json.Unmarshal(data, &labelled)
+	if err == nil {
+		*images = labelled
+		return nil
+	}
+
+	var list []string
+	if json.Unmarshal(data, &list) != nil {
+		return err
+	}
+
+	*images = DockerImageMap{}
+	for _, image := range list {
+		(*images)[image] = image
+	}
+	return nil
+}
+
+type DockerImage struct {
+	Label   string
+	Image   string
+	Current bool
+}
+
+func GetServerStartup(pterodactylServer PterodactylServer, server Server) (ServerStartup, error) {
+	var startup ServerStartup
+	if err := requireFeature(pterodactylServer, FeatureStartup); err != nil {
+		return startup, err
+	}
+
+	err := callApi(&startup, pterodactylServer, http.MethodGet, ApiEndpointServer, []string{server.Attributes.UUID, "startup"}, nil)
+	if err != nil {
+		return startup, err
+	}
+
+	return startup, nil
+}
+
+// GetServerDockerImages lists the images the server can be switched to, sorted by label.
+func GetServerDockerImages(pterodactylServer PterodactylServer, server Server) ([]DockerImage, error) {
+	startup, err := GetServerStartup(pterodactylServer, server)
+	if err != nil {
+		return nil, err
+	}
+
+	var images []DockerImage
+	for label, image := range startup.Meta.DockerImages {
+		images = append(images, DockerImage{Label: label, Image: image, Current: image == server.Attributes.DockerImage})
+	}
+	sort.Slice(images, func(i, j int) bool {
+		return images[i].Label < images[j].Label
+	})
+
+	return images, nil
+}
+
+// SetServerDockerImage switches the server to one of the images offered by its egg, the
+// change applies on the next start.
+func SetServerDockerImage(pterodactylServer PterodactylServer, server Server, image string) error {
+	if err := requireFeature(pterodactylServer, FeatureStartup); err != nil {
+		return err
+	}
+
+	var response struct{}
+	return callApi(&response, pterodactylServer, http.MethodPut, ApiEndpointServer, []string{server.Attributes.UUID, "settings", "docker-image"}, map[string]string{"docker_image": image})
+}
@@ -0,0 +1,83 @@
+package pterodactyl
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestDockerImageMap(t *testing.T) {
+	tests := map[string]struct {
+		data     string
+		expected DockerImageMap
+	}{
+		"labelled": {
+			data:     `{"Java 17": "ghcr.io/pterodactyl/yolks:java_17", "Java 21": "ghcr.io/pterodactyl/yolks:java_21"}`,
+			expected: DockerImageMap{"Java 17": "ghcr.io/pterodactyl/yolks:java_17", "Java 21": "ghcr.io/pterodactyl/yolks:java_21"},
+		},
+		"list": {
+			data:     `["ghcr.io/pterodactyl/yolks:java_17", "ghcr.io/pterodactyl/yolks:java_21"]`,
+			expected: DockerImageMap{"ghcr.io/pterodactyl/yolks:java_17": "ghcr.io/pterodactyl/yolks:java_17", "ghcr.io/pterodactyl/yolks:java_21": "ghcr.io/pterodactyl/yolks:java_21"},
+		},
+		"empty list": {
+			data:     `[]`,
+			expected: DockerImageMap{},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var images DockerImageMap
+			err := json.Unmarshal([]byte(test.data), &images)
+			if err != nil {
+				t.Fatalf("json.Unmarshal() error = %s", err)
+			}
+			if !reflect.DeepEqual(images, test.expected) {
+				t.Errorf("json.Unmarshal() = %v, want %v", images, test.expected)
+			}
+		})
+	}
+
+	var images DockerImageMap
+	if err := json.Unmarshal([]byte(`"ghcr.io/pterodactyl/yolks:java_17"`), &images); err == nil {
+		t.Errorf("json.Unmarshal() of a string = %v, want an error", images)
+	}
+}
+
+func TestGetServerDockerImages(t *testing.T) {
+	server := decodeServer(t, `{"attributes": {"uuid": "c9b1d8e2-0000", "docker_image": "ghcr.io/pterodactyl/yolks:java_17"}}`)
+
+	tests := map[string]struct {
+		images   string
+		expected []DockerImage
+	}{
+		"labelled": {
+			images: `{"Java 21": "ghcr.io/pterodactyl/yolks:java_21", "Java 17": "ghcr.io/pterodactyl/yolks:java_17"}`,
+			expected: []DockerImage{
+				{Label: "Java 17", Image: "ghcr.io/pterodactyl/yolks:java_17", Current: true},
+				{Label: "Java 21", Image: "ghcr.io/pterodactyl/yolks:java_21"},
+			},
+		},
+		"list": {
+			images: `["ghcr.io/pterodactyl/yolks:java_21", "ghcr.io/pterodactyl/yolks:java_17"]`,
+			expected: []DockerImage{
+				{Label: "ghcr.io/pterodactyl/yolks:java_17", Image: "ghcr.io/pterodactyl/yolks:java_17", Current: true},
+				{Label: "ghcr.io/pterodactyl/yolks:java_21", Image: "ghcr.io/pterodactyl/yolks:java_21"},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pterodactylServer := panelServing(t, `{"object": "list", "data": [], "meta": {"startup_command": "java -jar server.jar", "docker_images": `+test.images+`}}`)
+
+			images, err := GetServerDockerImages(pterodactylServer, server)
+			if err != nil {
+				t.Fatalf("GetServerDockerImages() error = %s", err)
+			}
+			if !reflect.DeepEqual(images, test.expected) {
+				t.Errorf("GetServerDockerImages() = %+v, want %+v", images, test.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,562 @@
+package pterodactyl
+
+import (
+	"context"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+)
+
+type OverwritePolicy string
+
+const (
+	// OverwriteReplace replaces an existing destination once the download completed
+	OverwriteReplace OverwritePolicy = "overwrite"
+	// OverwriteFail refuses to download when the destination exists
+	OverwriteFail OverwritePolicy = "fail"
+	// OverwriteResume keeps an existing destination that matches the remote file and continues
+	// an interrupted download
+	OverwriteResume OverwritePolicy = "resume"
+)
+
+// errDownloadMismatch reports a local file that does not match the remote one, a resumed
+// download restarts from scratch when it happens.
+var errDownloadMismatch = errors.New("downloaded file does not match the remote file")
+
+type downloadOptions struct {
+	pterodactylServer PterodactylServer
+	overwrite         OverwritePolicy
+	workers           int
+	chunkSize         int64
+	checksum          string
+}
+
+type DownloadOption func(options *downloadOptions)
+
+func WithOverwritePolicy(policy OverwritePolicy) DownloadOption {
+	return func(options *downloadOptions) {
+		options.overwrite = policy
+	}
+}
+
+// WithChecksum verifies the downloaded file against a checksum, either a SHA-256 hex string
+// or an "algorithm:hex" string such as the "sha1:..." hashes panels report for backups.
+func WithChecksum(checksum string) DownloadOption {
+	return func(options *downloadOptions) {
+		options.checksum = checksum
+	}
+}
+
+// WithParallelChunks downloads chunkSize byte ranges with several concurrent requests. It
+// falls back to a single stream when the storage behind the signed URL ignores ranges, and
+// interrupted downloads are resumed as a single stream.
+func WithParallelChunks(workers int, chunkSize int64) DownloadOption {
+	return func(options *downloadOptions) {
+		options.workers = workers
+		options.chunkSize = chunkSize
+	}
+}
+
+// openDownloadRange requests bytes start to end included, or up to the end of the file when
+// end is negative.
+func openDownloadRange(ctx context.Context, pterodactylServer PterodactylServer, downloadUrl string, start int64, end int64) (*http.Response, error) {
+	log.Trace(fmt.Sprintf("openDownload -> Attempting to download: '%s'", downloadUrl))
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, downloadUrl, nil)
+	req.Header.Add("Accept", "application/json")
+	if end >= 0 {
+		req.Header.Add("Range", fmt.Sprintf("bytes=%d-%d", start, end))
+	} else if start > 0 {
+		req.Header.Add("Range", fmt.Sprintf("bytes=%d-", start))
+	}
+	res, err := pterodactylServer.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Trace(fmt.Sprintf("openDownload -> Status Code: '%d'", res.StatusCode))
+	// Asking for bytes past the end means the partial file already holds everything
+	complete := start > 0 && res.StatusCode == http.StatusRequestedRangeNotSatisfiable
+	if res.StatusCode != http.StatusOK && res.StatusCode != http.StatusPartialContent && !complete {
+		res.Body.Close()
+		return nil, &DownloadError{StatusCode: res.StatusCode}
+	}
+
+	return res, nil
+}
+
+// maxDownloadUrlRefreshes is how many times a single request is retried with a new URL, so
+// long downloads made of many chunks can outlive several URLs.
+const maxDownloadUrlRefreshes = 3
+
+// signedUrl hands out a signed download URL and asks the panel for a new one once the
+// storage reports it expired.
+type signedUrl struct {
+	fetch func() (string, error)
+
+	mutex sync.Mutex
+	url   string
+}
+
+func newSignedUrl(fetch func() (string, error)) (*signedUrl, error) {
+	downloadUrl, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+	return &signedUrl{fetch: fetch, url: downloadUrl}, nil
+}
+
+func (signed *signedUrl) get() string {
+	signed.mutex.Lock()
+	defer signed.mutex.Unlock()
+
+	return signed.url
+}
+
+// refresh replaces the expired URL, unless a concurrent chunk already did.
+func (signed *signedUrl) refresh(expired string) error {
+	signed.mutex.Lock()
+	defer signed.mutex.Unlock()
+
+	if signed.url != expired {
+		return nil
+	}
+
+	log.Trace("signedUrl -> Download url expired, requesting a new one")
+	downloadUrl, err := signed.fetch()
+	if err != nil {
+		return err
+	}
+	signed.url = downloadUrl
+	return nil
+}
+
+func isExpiredUrl(err error) bool {
+	return IsStatusCode(err, http.StatusForbidden) || IsStatusCode(err, http.StatusGone)
+}
+
+// openSignedRange is openDownloadRange retrying with a fresh URL when the current one expired.
+func openSignedRange(ctx context.Context, pterodactylServer PterodactylServer, signed *signedUrl, start int64, end int64) (*http.Response, error) {
+	for refreshes := 0; ; refreshes++ {
+		downloadUrl := signed.get()
+		res, err := openDownloadRange(ctx, pterodactylServer, downloadUrl, start, end)
+		if !isExpiredUrl(err) {
+			return res, err
+		}
+		if refreshes == maxDownloadUrlRefreshes {
+			return nil, fmt.Errorf("%w: download url still rejected after %d refreshes", err, refreshes)
+		}
+
+		refreshErr := signed.refresh(downloadUrl)
+		if refreshErr != nil {
+			return nil, fmt.Errorf("%w: %s", err, refreshErr)
+		}
+	}
+}
+
+// closedFile keeps the historical contract of the download functions, which return the
+// closed destination file.
+func closedFile(path string) (*os.File, error) {
+	out, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	return out, out.Close()
+}
+
+// parseContentRange reads "bytes start-end/total" and "bytes */total" headers, an unknown
+// total is returned as -1.
+func parseContentRange(contentRange string) (int64, int64, int64, error) {
+	invalid := fmt.Errorf("invalid content range '%s'", contentRange)
+
+	unit, value, ok := strings.Cut(contentRange, " ")
+	if !ok || unit != "bytes" {
+		return 0, 0, 0, invalid
+	}
+	span, size, ok := strings.Cut(value, "/")
+	if !ok {
+		return 0, 0, 0, invalid
+	}
+
+	total := int64(-1)
+	if size != "*" {
+		parsed, err := strconv.ParseInt(size, 10, 64)
+		if err != nil {
+			return 0, 0, 0, invalid
+		}
+		total = parsed
+	}
+	if span == "*" {
+		return 0, -1, total, nil
+	}
+
+	first, last, ok := strings.Cut(span, "-")
+	start, startErr := strconv.ParseInt(first, 10, 64)
+	end, endErr := strconv.ParseInt(last, 10, 64)
+	if !ok || startErr != nil || endErr != nil || start > end || (total >= 0 && end >= total) {
+		return 0, 0, 0, invalid
+	}
+	return start, end, total, nil
+}
+
+func checksumHash(checksum string) (hash.Hash, string, error) {
+	algorithm, sum, ok := strings.Cut(checksum, ":")
+	if !ok {
+		algorithm, sum = "sha256", checksum
+	}
+
+	switch strings.ToLower(algorithm) {
+	case "sha1":
+		return sha1.New(), sum, nil
+	case "sha256":
+		return sha256.New(), sum, nil
+	}
+	return nil, "", fmt.Errorf("unsupported checksum algorithm '%s'", algorithm)
+}
+
+// verifyDownload compares a file with the remote size and the checksum, when they are known.
+func verifyDownload(path string, size int64, checksum string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	if size >= 0 && info.Size() != size {
+		return fmt.Errorf("%w: '%s' has %d bytes, expected %d", errDownloadMismatch, path, info.Size(), size)
+	}
+
+	if checksum == "" {
+		return nil
+	}
+	digest, expected, err := checksumHash(checksum)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(digest, file)
+	if err != nil {
+		return err
+	}
+	if actual := hex.EncodeToString(digest.Sum(nil)); !strings.EqualFold(actual, expected) {
+		return fmt.Errorf("%w: '%s' has checksum %s, expected %s", errDownloadMismatch, path, actual, expected)
+	}
+	return nil
+}
+
+// remoteSize asks for the first byte to learn the size of the remote file, -1 when the
+// storage does not tell.
+func remoteSize(pterodactylServer PterodactylServer, signed *signedUrl) (int64, error) {
+	res, err := openSignedRange(context.Background(), pterodactylServer, signed, 0, 0)
+	if err != nil {
+		return -1, err
+	}
+	res.Body.Close()
+
+	if res.StatusCode == http.StatusPartialContent {
+		_, _, total, err := parseContentRange(res.Header.Get("Content-Range"))
+		return total, err
+	}
+	return res.ContentLength, nil
+}
+
+// downloadToFile writes into a ".part" file next to the destination and only renames it
+// once the download completed, so the destination is never left half written. The signed URL
+// is only requested once the destination was checked.
+func downloadToFile(pterodactylServer PterodactylServer, fetchUrl func() (string, error), destination string, optionFuncs []DownloadOption) (*os.File, error) {
+	options := downloadOptions{pterodactylServer: pterodactylServer, overwrite: OverwriteReplace}
+	for _, option := range optionFuncs {
+		option(&options)
+	}
+
+	_, err := os.Stat(destination)
+	exists := err == nil
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	if exists && options.overwrite == OverwriteFail {
+		return nil, fmt.Errorf("download destination '%s': %w", destination, os.ErrExist)
+	}
+
+	signed, err := newSignedUrl(fetchUrl)
+	if err != nil {
+		return nil, err
+	}
+
+	if exists && options.overwrite == OverwriteResume {
+		size, err := remoteSize(options.pterodactylServer, signed)
+		if err != nil {
+			return nil, err
+		}
+
+		err = verifyDownload(destination, size, options.checksum)
+		if err == nil {
+			log.Trace(fmt.Sprintf("downloadToFile -> '%s' is already complete", destination))
+			return closedFile(destination)
+		}
+		if !errors.Is(err, errDownloadMismatch) {
+			return nil, err
+		}
+		log.Debugf("downloadToFile -> Downloading '%s' again: %s", destination, err)
+	}
+
+	partial := fmt.Sprintf("%s.part", destination)
+	var offset int64
+	if options.overwrite == OverwriteResume {
+		if info, err := os.Stat(partial); err == nil {
+			offset = info.Size()
+		}
+	}
+
+	if options.workers > 1 && options.chunkSize > 0 && offset == 0 {
+		// Chunks leave holes until every one of them is written, so they get a file of their
+		// own that is never resumed
+		chunked := fmt.Sprintf("%s.chunks", partial)
+		err = downloadChunks(signed, chunked, options)
+		if err == nil {
+			err = verifyDownload(chunked, -1, options.checksum)
+		}
+		if err != nil {
+			os.Remove(chunked)
+			return nil, err
+		}
+		return finishDownload(chunked, destination)
+	}
+
+	err = downloadStream(signed, partial, offset, options)
+	if offset > 0 && errors.Is(err, errDownloadMismatch) {
+		log.Debugf("downloadToFile -> Restarting '%s': %s", destination, err)
+		err = downloadStream(signed, partial, 0, options)
+	}
+	if err != nil {
+		// A resumable download keeps what it got so far, unless it is known to be wrong
+		if options.overwrite != OverwriteResume || errors.Is(err, errDownloadMismatch) {
+			os.Remove(partial)
+		}
+		return nil, err
+	}
+
+	return finishDownload(partial, destination)
+}
+
+// downloadStream writes the remote file into partial with a single request, appending to the
+// first offset bytes already there. The result is checked against the size the storage
+// reports and the checksum.
+func downloadStream(signed *signedUrl, partial string, offset int64, options downloadOptions) error {
+	res, err := openSignedRange(context.Background(), options.pterodactylServer, signed, offset, -1)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	size := int64(-1)
+	flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	switch res.StatusCode {
+	case http.StatusRequestedRangeNotSatisfiable:
+		// The partial file holds everything, or more than the remote file when it changed
+		_, _, size, err = parseContentRange(res.Header.Get("Content-Range"))
+		if err != nil {
+			return fmt.Errorf("%w: %s", errDownloadMismatch, err)
+		}
+		return verifyDownload(partial, size, options.checksum)
+	case http.StatusPartialContent:
+		start, _, total, err := parseContentRange(res.Header.Get("Content-Range"))
+		if err != nil {
+			return err
+		}
+		if start != offset {
+			return fmt.Errorf("%w: asked for bytes from %d, got them from %d", errDownloadMismatch, offset, start)
+		}
+		log.Trace(fmt.Sprintf("downloadStream -> Resuming '%s' at %d bytes", partial, offset))
+		size = total
+		flags = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	default:
+		if res.ContentLength >= 0 {
+			size = res.ContentLength
+		}
+	}
+
+	out, err := os.OpenFile(partial, flags, 0644)
+	log.Trace(fmt.Sprintf("downloadStream -> Creating file: '%s'", partial))
+	if err != nil {
+		return err
+	}
+
+	log.Trace(fmt.Sprintf("downloadStream -> Copying response body to file: '%s'", partial))
+	_, err = io.Copy(out, res.Body)
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	return verifyDownload(partial, size, options.checksum)
+}
+
+func finishDownload(partial string, destination string) (*os.File, error) {
+	err := os.Rename(partial, destination)
+	if err != nil {
+		return nil, err
+	}
+
+	return closedFile(destination)
+}
+
+type downloadChunk struct {
+	start int64
+	end   int64
+}
+
+func copyChunk(out *os.File, body io.Reader, chunk downloadChunk) error {
+	written, err := io.Copy(io.NewOffsetWriter(out, chunk.start), body)
+	if err != nil {
+		return err
+	}
+
+	if expected := chunk.end - chunk.start + 1; written != expected {
+		return fmt.Errorf("chunk at %d has %d bytes, expected %d", chunk.start, written, expected)
+	}
+	return nil
+}
+
+func downloadChunks(signed *signedUrl, partial string, options downloadOptions) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	first := downloadChunk{start: 0, end: options.chunkSize - 1}
+	res, err := openSignedRange(ctx, options.pterodactylServer, signed, first.start, first.end)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	out, err := os.OpenFile(partial, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	if res.StatusCode == http.StatusOK {
+		log.Trace("downloadChunks -> Ranges are not supported, downloading as a single stream")
+		_, err = io.Copy(out, res.Body)
+		if closeErr := out.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return err
+		}
+		return verifyDownload(partial, res.ContentLength, "")
+	}
+
+	start, end, total, err := parseContentRange(res.Header.Get("Content-Range"))
+	if err != nil || start != first.start || total < 0 {
+		log.Debugf("downloadChunks -> Unusable content range '%s', downloading as a single stream", res.Header.Get("Content-Range"))
+		res.Body.Close()
+		out.Close()
+		return downloadStream(signed, partial, 0, options)
+	}
+	first.end = end
+
+	err = out.Truncate(total)
+	if err != nil {
+		return err
+	}
+
+	var chunks []downloadChunk
+	for start := first.end + 1; start < total; start += options.chunkSize {
+		end := start + options.chunkSize - 1
+		if end >= total {
+			end = total - 1
+		}
+		chunks = append(chunks, downloadChunk{start: start, end: end})
+	}
+	log.Trace(fmt.Sprintf("downloadChunks -> Downloading %d bytes in %d chunks with %d workers", total, len(chunks)+1, options.workers))
+
+	queue := make(chan downloadChunk, len(chunks))
+	for _, chunk := range chunks {
+		queue <- chunk
+	}
+	close(queue)
+
+	// The first failure is reported before the other chunks are canceled, so it comes first
+	errs := make(chan error, options.workers+1)
+	fail := func(err error) {
+		errs <- err
+		cancel()
+	}
+
+	var waitGroup sync.WaitGroup
+	waitGroup.Add(1)
+	go func() {
+		defer waitGroup.Done()
+
+		if err := copyChunk(out, res.Body, first); err != nil {
+			fail(err)
+		}
+	}()
+
+	for i := 0; i < options.workers; i++ {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+
+			for chunk := range queue {
+				if ctx.Err() != nil {
+					return
+				}
+
+				err := fetchChunk(ctx, signed, out, chunk, options)
+				if err != nil {
+					fail(err)
+					return
+				}
+			}
+		}()
+	}
+
+	waitGroup.Wait()
+	close(errs)
+
+	if err, failed := <-errs; failed {
+		return err
+	}
+
+	return out.Close()
+}
+
+func fetchChunk(ctx context.Context, signed *signedUrl, out *os.File, chunk downloadChunk, options downloadOptions) error {
+	res, err := openSignedRange(ctx, options.pterodactylServer, signed, chunk.start, chunk.end)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusPartialContent {
+		return fmt.Errorf("chunk at %d was answered with status code %d", chunk.start, res.StatusCode)
+	}
+	start, _, _, err := parseContentRange(res.Header.Get("Content-Range"))
+	if err != nil {
+		return err
+	}
+	if start != chunk.start {
+		return fmt.Errorf("chunk at %d was answered from %d", chunk.start, start)
+	}
+
+	return copyChunk(out, res.Body, chunk)
+}
@@ -0,0 +1,307 @@
+package pterodactyl
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+var downloadContent = bytes.Repeat([]byte("0123456789abcdef"), 64)
+
+func downloadServer(t *testing.T, content []byte) (func() (string, error), *[]string) {
+	t.Helper()
+
+	var ranges []string
+	storage := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ranges = append(ranges, r.Header.Get("Range"))
+		http.ServeContent(w, r, "backup.tar.gz", time.Time{}, bytes.NewReader(content))
+	}))
+	t.Cleanup(storage.Close)
+
+	return func() (string, error) { return storage.URL, nil }, &ranges
+}
+
+func downloadChecksum(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+func readDownload(t *testing.T, path string) []byte {
+	t.Helper()
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading '%s': %s", path, err)
+	}
+	return content
+}
+
+func TestDownloadToFileResume(t *testing.T) {
+	tests := map[string]struct {
+		part     []byte
+		checksum string
+		requests int
+	}{
+		"matching part":       {part: downloadContent[:100], checksum: downloadChecksum(downloadContent), requests: 1},
+		"complete part":       {part: downloadContent, checksum: downloadChecksum(downloadContent), requests: 1},
+		"corrupted part":      {part: bytes.Repeat([]byte("x"), 100), checksum: downloadChecksum(downloadContent), requests: 2},
+		"part past the end":   {part: append(append([]byte(nil), downloadContent...), "trailing"...), requests: 2},
+		"part without a hash": {part: downloadContent[:100], requests: 1},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			fetchUrl, ranges := downloadServer(t, downloadContent)
+			destination := filepath.Join(t.TempDir(), "backup.tar.gz")
+			err := os.WriteFile(destination+".part", test.part, 0644)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = downloadToFile(PterodactylServer{}, fetchUrl, destination, []DownloadOption{WithOverwritePolicy(OverwriteResume), WithChecksum(test.checksum)})
+			if err != nil {
+				t.Fatalf("downloadToFile() error = %s", err)
+			}
+
+			if !bytes.Equal(readDownload(t, destination), downloadContent) {
+				t.Error("downloadToFile() content does not match the remote file")
+			}
+			if len(*ranges) != test.requests {
+				t.Errorf("downloadToFile() sent %d requests with ranges %q, want %d", len(*ranges), *ranges, test.requests)
+			}
+		})
+	}
+}
+
+func TestDownloadToFileExistingDestination(t *testing.T) {
+	tests := map[string]struct {
+		existing   []byte
+		checksum   string
+		downloaded bool
+	}{
+		"complete":      {existing: downloadContent, checksum: downloadChecksum(downloadContent)},
+		"truncated":     {existing: downloadContent[:100], downloaded: true},
+		"same size":     {existing: bytes.Repeat([]byte("x"), len(downloadContent)), checksum: downloadChecksum(downloadContent), downloaded: true},
+		"size unhashed": {existing: bytes.Repeat([]byte("x"), len(downloadContent))},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			fetchUrl, _ := downloadServer(t, downloadContent)
+			destination := filepath.Join(t.TempDir(), "backup.tar.gz")
+			err := os.WriteFile(destination, test.existing, 0644)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = downloadToFile(PterodactylServer{}, fetchUrl, destination, []DownloadOption{WithOverwritePolicy(OverwriteResume), WithChecksum(test.checksum)})
+			if err != nil {
+				t.Fatalf("downloadToFile() error = %s", err)
+			}
+
+			expected := test.existing
+			if test.downloaded {
+				expected = downloadContent
+			}
+			if !bytes.Equal(readDownload(t, destination), expected) {
+				t.Errorf("downloadToFile() replaced the destination = %t, want %t", !test.downloaded, test.downloaded)
+			}
+		})
+	}
+}
+
+func TestDownloadToFileChecksumMismatch(t *testing.T) {
+	fetchUrl, _ := downloadServer(t, downloadContent)
+	destination := filepath.Join(t.TempDir(), "backup.tar.gz")
+
+	_, err := downloadToFile(PterodactylServer{}, fetchUrl, destination, []DownloadOption{WithChecksum("sha1:0000")})
+	if err == nil {
+		t.Fatal("downloadToFile() error = nil, want a checksum mismatch")
+	}
+	if _, err := os.Stat(destination); err == nil {
+		t.Error("downloadToFile() created the destination of a mismatching download")
+	}
+	if _, err := os.Stat(destination + ".part"); err == nil {
+		t.Error("downloadToFile() kept the partial file of a mismatching download")
+	}
+}
+
+func TestParseContentRange(t *testing.T) {
+	start, end, total, err := parseContentRange("bytes 100-199/1024")
+	if err != nil || start != 100 || end != 199 || total != 1024 {
+		t.Errorf("parseContentRange() = %d, %d, %d, %v", start, end, total, err)
+	}
+
+	_, _, total, err = parseContentRange("bytes */1024")
+	if err != nil || total != 1024 {
+		t.Errorf("parseContentRange() of an unsatisfied range total = %d, %v", total, err)
+	}
+
+	for _, header := range []string{"", "bytes 100-199", "items 0-1/2", "bytes 200-100/1024", "bytes 0-1024/1024"} {
+		if _, _, _, err := parseContentRange(header); err == nil {
+			t.Errorf("parseContentRange(%q) error = nil, want an error", header)
+		}
+	}
+}
+
+func TestDownloadToFileChunks(t *testing.T) {
+	tests := map[string]http.HandlerFunc{
+		"ranges": func(w http.ResponseWriter, r *http.Request) {
+			http.ServeContent(w, r, "backup.tar.gz", time.Time{}, bytes.NewReader(downloadContent))
+		},
+		"ranges ignored": func(w http.ResponseWriter, r *http.Request) {
+			w.Write(downloadContent)
+		},
+		"invalid content range": func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Range") == "" {
+				w.Write(downloadContent)
+				return
+			}
+			w.Header().Set("Content-Range", "bytes 0-99")
+			w.WriteHeader(http.StatusPartialContent)
+			w.Write(downloadContent[:100])
+		},
+	}
+
+	for name, handler := range tests {
+		t.Run(name, func(t *testing.T) {
+			storage := httptest.NewServer(handler)
+			defer storage.Close()
+
+			destination := filepath.Join(t.TempDir(), "backup.tar.gz")
+			fetchUrl := func() (string, error) { return storage.URL, nil }
+			_, err := downloadToFile(PterodactylServer{}, fetchUrl, destination, []DownloadOption{WithParallelChunks(3, 100), WithChecksum(downloadChecksum(downloadContent))})
+			if err != nil {
+				t.Fatalf("downloadToFile() error = %s", err)
+			}
+			if !bytes.Equal(readDownload(t, destination), downloadContent) {
+				t.Error("downloadToFile() content does not match the remote file")
+			}
+		})
+	}
+}
+
+func TestDownloadToFileChunksCancelOnFailure(t *testing.T) {
+	storage := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Header.Get("Range") {
+		case "bytes=0-99":
+			http.ServeContent(w, r, "backup.tar.gz", time.Time{}, bytes.NewReader(downloadContent))
+		case "bytes=100-199":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			// Only returns once the download gave up on the chunk
+			<-r.Context().Done()
+		}
+	}))
+	defer storage.Close()
+
+	destination := filepath.Join(t.TempDir(), "backup.tar.gz")
+	fetchUrl := func() (string, error) { return storage.URL, nil }
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := downloadToFile(PterodactylServer{}, fetchUrl, destination, []DownloadOption{WithParallelChunks(3, 100)})
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if !IsStatusCode(err, http.StatusInternalServerError) {
+			t.Errorf("downloadToFile() error = %v, want the failed chunk status code", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("downloadToFile() did not cancel the remaining chunks")
+	}
+}
+
+func TestDownloadToFileChunksResume(t *testing.T) {
+	fetchUrl, ranges := downloadServer(t, downloadContent)
+	destination := filepath.Join(t.TempDir(), "backup.tar.gz")
+	err := os.WriteFile(destination+".part", downloadContent[:100], 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = downloadToFile(PterodactylServer{}, fetchUrl, destination, []DownloadOption{WithOverwritePolicy(OverwriteResume), WithParallelChunks(3, 100)})
+	if err != nil {
+		t.Fatalf("downloadToFile() error = %s", err)
+	}
+
+	if !bytes.Equal(readDownload(t, destination), downloadContent) {
+		t.Error("downloadToFile() content does not match the remote file")
+	}
+	if expected := []string{"bytes=100-"}; !reflect.DeepEqual(*ranges, expected) {
+		t.Errorf("downloadToFile() ranges = %q, want %q", *ranges, expected)
+	}
+}
+
+func TestDownloadToFileRefreshesPerRequest(t *testing.T) {
+	var mutex sync.Mutex
+	var version, uses int
+	storage := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		// Every url is good for two requests
+		valid := r.URL.Query().Get("v") == strconv.Itoa(version) && uses < 2
+		uses++
+		mutex.Unlock()
+
+		if !valid {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		http.ServeContent(w, r, "backup.tar.gz", time.Time{}, bytes.NewReader(downloadContent))
+	}))
+	defer storage.Close()
+
+	fetchUrl := func() (string, error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		version++
+		uses = 0
+		return fmt.Sprintf("%s?v=%d", storage.URL, version), nil
+	}
+
+	destination := filepath.Join(t.TempDir(), "backup.tar.gz")
+	_, err := downloadToFile(PterodactylServer{}, fetchUrl, destination, []DownloadOption{WithParallelChunks(2, 100)})
+	if err != nil {
+		t.Fatalf("downloadToFile() error = %s", err)
+	}
+	if !bytes.Equal(readDownload(t, destination), downloadContent) {
+		t.Error("downloadToFile() content does not match the remote file")
+	}
+	if version <= maxDownloadUrlRefreshes+1 {
+		t.Errorf("downloadToFile() requested %d urls, the test needs more than %d", version, maxDownloadUrlRefreshes+1)
+	}
+}
+
+func TestDownloadToFileRefreshLimit(t *testing.T) {
+	storage := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer storage.Close()
+
+	var fetches int
+	fetchUrl := func() (string, error) {
+		fetches++
+		return storage.URL, nil
+	}
+
+	_, err := downloadToFile(PterodactylServer{}, fetchUrl, filepath.Join(t.TempDir(), "backup.tar.gz"), nil)
+	if !IsStatusCode(err, http.StatusForbidden) {
+		t.Errorf("downloadToFile() error = %v, want status code %d", err, http.StatusForbidden)
+	}
+	if fetches != maxDownloadUrlRefreshes+1 {
+		t.Errorf("downloadToFile() requested %d urls, want %d", fetches, maxDownloadUrlRefreshes+1)
+	}
+}
@@ -0,0 +1,118 @@
+package pterodactyl
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// EggCache keeps resolved eggs by panel, nest and egg name. Share one between goroutines
+// through PterodactylServer.EggCache.
+type EggCache struct {
+	mutex sync.Mutex
+	eggs  map[string]Egg
+}
+
+func NewEggCache() *EggCache {
+	return &EggCache{eggs: map[string]Egg{}}
+}
+
+func (cache *EggCache) get(key string) (Egg, bool) {
+	if cache == nil {
+		return Egg{}, false
+	}
+
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	egg, ok := cache.eggs[key]
+	return egg, ok
+}
+
+func (cache *EggCache) store(key string, egg Egg) {
+	if cache == nil {
+		return
+	}
+
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	if cache.eggs == nil {
+		cache.eggs = map[string]Egg{}
+	}
+	cache.eggs[key] = egg
+}
+
+// Clear forgets every cached egg, use it after changing nests or eggs on the panel.
+func (cache *EggCache) Clear() {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	cache.eggs = map[string]Egg{}
+}
+
+func GetNests(pterodactylServer PterodactylServer) ([]Nest, error) {
+	if err := requireFeature(pterodactylServer, FeatureApplicationApi); err != nil {
+		return nil, err
+	}
+
+	return getAllPages[Nest](pterodactylServer, ApiEndpointNests, nil, nil)
+}
+
+func GetNestEggs(pterodactylServer PterodactylServer, nestId int) ([]Egg, error) {
+	if err := requireFeature(pterodactylServer, FeatureApplicationApi); err != nil {
+		return nil, err
+	}
+
+	var eggs ListResponse[Egg]
+	err := callApi(&eggs, pterodactylServer, http.MethodGet, ApiEndpointNests, []string{strconv.Itoa(nestId), ApiEndpointEggs}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return eggs.Data, nil
+}
+
+func eggCacheKey(pterodactylServer PterodactylServer, nestName string, eggName string) string {
+	return strings.ToLower(fmt.Sprintf("%s|%s|%s", pterodactylServer.Url, nestName, eggName))
+}
+
+// FindEgg resolves an egg from its nest and egg names, ignoring case. Resolved eggs are
+// kept in PterodactylServer.EggCache, every call asks the panel when it is nil.
+func FindEgg(pterodactylServer PterodactylServer, nestName string, eggName string) (Egg, error) {
+	key := eggCacheKey(pterodactylServer, nestName, eggName)
+
+	egg, ok := pterodactylServer.EggCache.get(key)
+	if ok {
+		return egg, nil
+	}
+
+	nests, err := GetNests(pterodactylServer)
+	if err != nil {
+		return Egg{}, err
+	}
+
+	for _, nest := range nests {
+		if !strings.EqualFold(nest.Attributes.Name, nestName) {
+			continue
+		}
+
+		eggs, err := GetNestEggs(pterodactylServer, nest.Attributes.ID)
+		if err != nil {
+			return Egg{}, err
+		}
+
+		for _, egg := range eggs {
+			if strings.EqualFold(egg.Attributes.Name, eggName) {
+				pterodactylServer.EggCache.store(key, egg)
+				return egg, nil
+			}
+		}
+
+		return Egg{}, fmt.Errorf("egg '%s' not found in nest '%s'", eggName, nestName)
+	}
+
+	return Egg{}, fmt.Errorf("nest '%s' not found", nestName)
+}
@@ -0,0 +1,57 @@
+package pterodactyl
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func eggPanel(t *testing.T, requests *int32) string {
+	t.Helper()
+
+	panel := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+		if strings.HasSuffix(r.URL.Path, "/eggs") {
+			fmt.Fprint(w, `{"data": [{"attributes": {"id": 7, "name": "Paper"}}]}`)
+			return
+		}
+		fmt.Fprint(w, `{"data": [{"attributes": {"id": 1, "name": "Minecraft"}}], "meta": {"pagination": {"total_pages": 1}}}`)
+	}))
+	t.Cleanup(panel.Close)
+
+	return panel.URL
+}
+
+func TestFindEgg(t *testing.T) {
+	tests := map[string]struct {
+		cache    *EggCache
+		expected int32
+	}{
+		"cached":   {cache: NewEggCache(), expected: 2},
+		"no cache": {cache: nil, expected: 4},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var requests int32
+			pterodactylServer := PterodactylServer{Url: eggPanel(t, &requests), EggCache: test.cache}
+
+			for i := 0; i < 2; i++ {
+				egg, err := FindEgg(pterodactylServer, "minecraft", "paper")
+				if err != nil {
+					t.Fatalf("FindEgg() error = %s", err)
+				}
+				if egg.Attributes.ID != 7 {
+					t.Errorf("FindEgg() id = %d, want 7", egg.Attributes.ID)
+				}
+			}
+
+			if requests := atomic.LoadInt32(&requests); requests != test.expected {
+				t.Errorf("FindEgg() sent %d requests, want %d", requests, test.expected)
+			}
+		})
+	}
+}
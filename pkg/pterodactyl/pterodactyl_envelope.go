@@ -0,0 +1,129 @@
+package pterodactyl
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// ListResponse is the envelope of list endpoints. T is the model of a single record, which
+// carries its own object and attributes, such as Server or Backup.
+type ListResponse[T any] struct {
+	Object string      `json:"object"`
+	Data   []T         `json:"data"`
+	Meta   ApiMetaData `json:"meta"`
+	// Skipped lists the records left out of Data because they could not be decoded.
+	Skipped []SkippedRecord `json:"-"`
+}
+
+type SkippedRecord struct {
+	// Index is the position of the record in the data of the response.
+	Index int
+	Raw   json.RawMessage
+	Err   error
+}
+
+// UnmarshalJSON skips the records that cannot be decoded, so one malformed record does not
+// fail the whole list. They are reported in Skipped.
+func (list *ListResponse[T]) UnmarshalJSON(data []byte) error {
+	var envelope struct {
+		Object string            `json:"object"`
+		Data   []json.RawMessage `json:"data"`
+		Meta   ApiMetaData       `json:"meta"`
+	}
+	err := decodeLenient(data, &envelope)
+	if err != nil {
+		return err
+	}
+
+	list.Object, list.Meta, list.Data, list.Skipped = envelope.Object, envelope.Meta, nil, nil
+	if envelope.Data != nil {
+		list.Data = make([]T, 0, len(envelope.Data))
+	}
+	for i, raw := range envelope.Data {
+		var record T
+		err = decodeLenient(raw, &record)
+		if err != nil {
+			log.Warnf("ListResponse -> Skipping record %d of '%s': %s", i, envelope.Object, err)
+			list.Skipped = append(list.Skipped, SkippedRecord{Index: i, Raw: raw, Err: err})
+			continue
+		}
+		list.Data = append(list.Data, record)
+	}
+
+	return nil
+}
+
+// ItemResponse is the envelope of single record endpoints. T is the model of the record, as
+// in ListResponse, and Meta holds what some endpoints send next to it, such as the
+// permissions of the user on a server. It is encoded as the panel sends it, with the object
+// and attributes of Data next to meta.
+type ItemResponse[T any] struct {
+	Data T
+	Meta json.RawMessage
+}
+
+func (item ItemResponse[T]) MarshalJSON() ([]byte, error) {
+	record, err := json.Marshal(item.Data)
+	if err != nil {
+		return nil, err
+	}
+	if len(item.Meta) == 0 {
+		return record, nil
+	}
+
+	var envelope map[string]json.RawMessage
+	err = json.Unmarshal(record, &envelope)
+	if err != nil {
+		return nil, err
+	}
+	envelope["meta"] = item.Meta
+
+	return json.Marshal(envelope)
+}
+
+func (item *ItemResponse[T]) UnmarshalJSON(data []byte) error {
+	var envelope struct {
+		Meta json.RawMessage `json:"meta"`
+	}
+	err := json.Unmarshal(data, &envelope)
+	if err != nil {
+		return err
+	}
+
+	var record T
+	err = decodeLenient(data, &record)
+	if err != nil {
+		return err
+	}
+
+	item.Data, item.Meta = record, envelope.Meta
+	return nil
+}
+
+// getAllPages follows the pagination of a list endpoint, query is copied before the page is set.
+func getAllPages[T any](pterodactylServer PterodactylServer, endpoint string, subPaths []string, query url.Values) ([]T, error) {
+	var records []T
+
+	for page := 1; ; page++ {
+		pageValues := pageQuery(page)
+		for key, values := range query {
+			pageValues[key] = values
+		}
+
+		var response ListResponse[T]
+		err := callApiWithQuery(&response, pterodactylServer, http.MethodGet, endpoint, subPaths, pageValues, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		records = append(records, response.Data...)
+		if page >= response.Meta.Pagination.TotalPages {
+			break
+		}
+	}
+
+	return records, nil
+}
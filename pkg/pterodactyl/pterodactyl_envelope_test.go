@@ -0,0 +1,100 @@
+package pterodactyl
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestItemResponse(t *testing.T) {
+	pterodactylServer := panelServing(t, `{"object": "server", "attributes": {"uuid": "c9b1d8e2-0000", "status": "suspended"}, "meta": {"is_server_owner": true}}`)
+
+	var item ItemResponse[Server]
+	_, err := Do(&item, pterodactylServer, http.MethodGet, ApiEndpointServer, []string{"c9b1d8e2"}, nil, nil)
+	if err != nil {
+		t.Fatalf("Do() error = %s", err)
+	}
+
+	if item.Data.Object != "server" || item.Data.Attributes.UUID != "c9b1d8e2-0000" || !item.Data.Attributes.IsSuspended {
+		t.Errorf("ItemResponse.Data = %+v, want the decoded server", item.Data)
+	}
+	if string(item.Meta) != `{"is_server_owner": true}` {
+		t.Errorf("ItemResponse.Meta = %s, want the meta object", item.Meta)
+	}
+}
+
+func TestItemResponseRoundTrip(t *testing.T) {
+	tests := map[string]string{
+		"with meta":    `{"object": "backup", "attributes": {"uuid": "904df120", "name": "nightly"}, "meta": {"is_locked": true}}`,
+		"without meta": `{"object": "backup", "attributes": {"uuid": "904df120", "name": "nightly"}}`,
+	}
+
+	for name, body := range tests {
+		t.Run(name, func(t *testing.T) {
+			var item ItemResponse[Backup]
+			err := json.Unmarshal([]byte(body), &item)
+			if err != nil {
+				t.Fatalf("json.Unmarshal() error = %s", err)
+			}
+
+			encoded, err := json.Marshal(item)
+			if err != nil {
+				t.Fatalf("json.Marshal() error = %s", err)
+			}
+
+			var envelope map[string]json.RawMessage
+			if err := json.Unmarshal(encoded, &envelope); err != nil {
+				t.Fatalf("json.Marshal() = %s, want an object: %s", encoded, err)
+			}
+			if _, ok := envelope["attributes"]; !ok || string(envelope["object"]) != `"backup"` {
+				t.Errorf("json.Marshal() = %s, want the object and attributes of the record", encoded)
+			}
+
+			var decoded ItemResponse[Backup]
+			err = json.Unmarshal(encoded, &decoded)
+			if err != nil {
+				t.Fatalf("json.Unmarshal() of the encoded item error = %s", err)
+			}
+			if !reflect.DeepEqual(decoded.Data, item.Data) || !bytes.Equal(compactJSON(t, decoded.Meta), compactJSON(t, item.Meta)) {
+				t.Errorf("round trip = %+v, want %+v", decoded, item)
+			}
+		})
+	}
+}
+
+func compactJSON(t *testing.T, data json.RawMessage) []byte {
+	t.Helper()
+
+	if len(data) == 0 {
+		return nil
+	}
+	var compacted bytes.Buffer
+	if err := json.Compact(&compacted, data); err != nil {
+		t.Fatalf("json.Compact() error = %s", err)
+	}
+	return compacted.Bytes()
+}
+
+func TestSingleRecordEndpointsDecodeItems(t *testing.T) {
+	pterodactylServer := panelServing(t, `{"object": "backup", "attributes": {"uuid": "904df120", "url": "https://wings.example/download",
+		"relationships": {"servers": {"object": "list", "data": [{"object": "server", "attributes": {"id": 3}}]}}}, "meta": {}}`)
+	var server Server
+	server.Attributes.UUID = "c9b1d8e2-0000"
+
+	backup, err := BackupServerWithName(pterodactylServer, server, "nightly")
+	if err != nil || backup.Attributes.UUID != "904df120" {
+		t.Errorf("BackupServerWithName() = %+v, %v, want backup 904df120", backup.Attributes, err)
+	}
+
+	downloadUrl, err := GetServerFileDownloadUrl(pterodactylServer, server, "/server.properties")
+	if err != nil || downloadUrl != "https://wings.example/download" {
+		t.Errorf("GetServerFileDownloadUrl() = %q, %v, want the signed url", downloadUrl, err)
+	}
+
+	servers, err := GetNodeServers(pterodactylServer, 1)
+	if err != nil || len(servers) != 1 || servers[0].Attributes.ID != 3 {
+		t.Errorf("GetNodeServers() = %+v, %v, want server 3", servers, err)
+	}
+}
@@ -0,0 +1,55 @@
+package pterodactyl
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+)
+
+type ApiRequestError struct {
+	StatusCode int
+	Header     http.Header
+	Errors     []ApiError
+	// Body is kept for responses injected by proxies, which do not follow the panel error format
+	Body []byte
+}
+
+func (e *ApiRequestError) Error() string {
+	return fmt.Sprintf("api call failed with status code %d and errors: %v", e.StatusCode, e.Errors)
+}
+
+// DownloadError is returned when the storage behind a signed download URL refuses it.
+type DownloadError struct {
+	StatusCode int
+}
+
+func (e *DownloadError) Error() string {
+	return fmt.Sprintf("download failed with status code %d", e.StatusCode)
+}
+
+func IsStatusCode(err error, statusCode int) bool {
+	var requestError *ApiRequestError
+	if errors.As(err, &requestError) {
+		return requestError.StatusCode == statusCode
+	}
+
+	var downloadError *DownloadError
+	return errors.As(err, &downloadError) && downloadError.StatusCode == statusCode
+}
+
+// IsTransientError reports whether err is worth retrying later: network failures, rate
+// limiting, an open circuit breaker and server side errors from the panel or a proxy.
+func IsTransientError(err error) bool {
+	if errors.Is(err, ErrCircuitOpen) {
+		return true
+	}
+
+	var requestError *ApiRequestError
+	if errors.As(err, &requestError) {
+		return requestError.StatusCode == http.StatusTooManyRequests || requestError.StatusCode >= http.StatusInternalServerError
+	}
+
+	var netError net.Error
+	return errors.As(err, &netError)
+}
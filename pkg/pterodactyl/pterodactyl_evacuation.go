@@ -0,0 +1,135 @@
+package pterodactyl
+
+import (
+	"errors"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+)
+
+type EvacuationAction string
+
+const (
+	EvacuateStop    EvacuationAction = "stop"
+	EvacuateSuspend EvacuationAction = "suspend"
+)
+
+type EvacuationOptions struct {
+	Action EvacuationAction
+	// ClientServer is used to stop servers, application API keys cannot send power signals.
+	ClientServer PterodactylServer
+	// Shutdown is applied to the servers stopped by EvacuateStop, with the ShutdownServers
+	// defaults. Its warnings are sent once to every server before the first one is stopped.
+	// Suspended servers cannot be warned, so EvacuateSuspend rejects warnings.
+	Shutdown ShutdownOptions
+	// Transfer is called with a planned target on another node for every evacuated server.
+	// The panel API has no transfer endpoint, so starting the transfer is left to the caller.
+	Transfer func(server ApplicationServer, target DeploymentTarget) error
+}
+
+type EvacuationResult struct {
+	Server  ApplicationServer
+	Outcome ShutdownOutcome
+	Target  *DeploymentTarget
+	Err     error
+}
+
+func (options EvacuationOptions) validate() error {
+	switch options.Action {
+	case EvacuateStop:
+		if options.ClientServer.Url == "" {
+			return errors.New("stopping servers requires a client server")
+		}
+		return requireFeature(options.ClientServer, FeatureResources)
+	case EvacuateSuspend:
+		if len(options.Shutdown.Warnings) > 0 {
+			return errors.New("shutdown warnings require the stop action")
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown evacuation action '%s'", options.Action)
+}
+
+func evacuateServer(pterodactylServer PterodactylServer, server ApplicationServer, clientServer Server, options EvacuationOptions) (ShutdownOutcome, error) {
+	if options.Action == EvacuateSuspend {
+		if server.Attributes.Suspended {
+			return ShutdownAlreadyOffline, nil
+		}
+
+		err := SuspendServer(pterodactylServer, server.Attributes.ID)
+		if err != nil {
+			return ShutdownFailed, err
+		}
+		return ShutdownStopped, nil
+	}
+
+	result := shutdownServer(options.ClientServer, clientServer, options.Shutdown)
+	return result.Outcome, result.Err
+}
+
+// EvacuateNode stops or suspends every server on a node. When a Transfer function is set,
+// each server that was taken down gets a target on another node planned for it.
+func EvacuateNode(pterodactylServer PterodactylServer, nodeId int, options EvacuationOptions) ([]EvacuationResult, error) {
+	var planner *DeploymentPlanner
+
+	if err := options.validate(); err != nil {
+		return nil, err
+	}
+	options.Shutdown = options.Shutdown.withDefaults()
+	if err := requireFeature(pterodactylServer, FeatureApplicationApi); err != nil {
+		return nil, err
+	}
+
+	servers, err := GetNodeServers(pterodactylServer, nodeId)
+	if err != nil {
+		return nil, err
+	}
+
+	if options.Transfer != nil {
+		planner, err = NewDeploymentPlanner(pterodactylServer)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	results := make([]EvacuationResult, len(servers))
+	clientServers := make([]Server, len(servers))
+	if options.Action == EvacuateStop {
+		var warned []Server
+		for i, server := range servers {
+			results[i].Server = server
+			clientServers[i], results[i].Err = GetServer(options.ClientServer, server.Attributes.UUID)
+			if results[i].Err != nil {
+				results[i].Outcome = ShutdownFailed
+				continue
+			}
+			warned = append(warned, clientServers[i])
+		}
+
+		sendShutdownWarnings(options.ClientServer, warned, options.Shutdown.Warnings)
+	}
+
+	for i, server := range servers {
+		result := &results[i]
+		result.Server = server
+		if result.Err == nil {
+			result.Outcome, result.Err = evacuateServer(pterodactylServer, server, clientServers[i], options)
+		}
+		log.Debugf("EvacuateNode -> '%s': %s", server.Attributes.Name, result.Outcome)
+
+		if result.Err == nil && planner != nil {
+			target, err := planner.Plan(DeploymentRequest{
+				Memory:         server.Attributes.Limits.Memory,
+				Disk:           server.Attributes.Limits.Disk,
+				ExcludeNodeIds: []int{nodeId},
+			})
+			if err == nil {
+				result.Target = &target
+				err = options.Transfer(server, target)
+			}
+			result.Err = err
+		}
+	}
+
+	return results, nil
+}
@@ -0,0 +1,159 @@
+package pterodactyl
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// evacuationPanel serves node 1 with servers 3 and 4, node 2 with a free allocation, and the
+// client API of both servers. It records the calls that change something on the panel.
+func evacuationPanel(t *testing.T) (PterodactylServer, *[]string) {
+	t.Helper()
+
+	var mutex sync.Mutex
+	var calls []string
+	resources := map[string]int{}
+	panel := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		path := strings.TrimPrefix(r.URL.Path, "/api/")
+		switch {
+		case path == "application/nodes/1":
+			fmt.Fprint(w, `{"object": "node", "attributes": {"id": 1, "relationships": {"servers": {"data": [
+				{"attributes": {"id": 3, "uuid": "lobby-uuid", "name": "lobby", "limits": {"memory": 1024, "disk": 2048}}},
+				{"attributes": {"id": 4, "uuid": "survival-uuid", "name": "survival", "suspended": true, "limits": {"memory": 1024, "disk": 2048}}}
+			]}}}}`)
+		case path == "application/nodes":
+			fmt.Fprint(w, `{"data": [
+				{"attributes": {"id": 1, "name": "node-1", "public": true, "memory": 64000, "memory_overallocate": 0, "disk": 64000, "disk_overallocate": 0}},
+				{"attributes": {"id": 2, "name": "node-2", "public": true, "memory": 8000, "memory_overallocate": 0, "disk": 8000, "disk_overallocate": 0}}
+			], "meta": {"pagination": {"total_pages": 1}}}`)
+		case path == "application/nodes/2/allocations":
+			fmt.Fprint(w, `{"data": [{"attributes": {"id": 20, "ip": "10.0.0.2", "port": 25565}}, {"attributes": {"id": 21, "ip": "10.0.0.2", "port": 25566}}],
+				"meta": {"pagination": {"total_pages": 1}}}`)
+		case strings.HasPrefix(path, "application/servers/"):
+			calls = append(calls, path)
+			w.WriteHeader(http.StatusNoContent)
+		case strings.HasSuffix(path, "/resources"):
+			state := PowerStateRunning
+			if resources[path] > 0 {
+				state = PowerStateOffline
+			}
+			resources[path]++
+			fmt.Fprintf(w, `{"attributes": {"current_state": "%s"}}`, state)
+		case strings.HasSuffix(path, "/power"), strings.HasSuffix(path, "/command"):
+			calls = append(calls, fmt.Sprintf("%s %s%s", path, r.FormValue("signal"), r.FormValue("command")))
+			w.WriteHeader(http.StatusNoContent)
+		case strings.HasPrefix(path, "client/servers/"):
+			uuid := strings.TrimPrefix(path, "client/servers/")
+			fmt.Fprintf(w, `{"attributes": {"uuid": "%s", "name": "%s"}}`, uuid, strings.TrimSuffix(uuid, "-uuid"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(panel.Close)
+
+	return PterodactylServer{Url: panel.URL, Clock: newFakeClock()}, &calls
+}
+
+func TestEvacuateNodeSuspend(t *testing.T) {
+	pterodactylServer, calls := evacuationPanel(t)
+
+	results, err := EvacuateNode(pterodactylServer, 1, EvacuationOptions{Action: EvacuateSuspend})
+	if err != nil {
+		t.Fatalf("EvacuateNode() error = %s", err)
+	}
+
+	if len(results) != 2 || results[0].Outcome != ShutdownStopped || results[1].Outcome != ShutdownAlreadyOffline {
+		t.Errorf("EvacuateNode() = %+v, want lobby suspended and survival already suspended", results)
+	}
+	if expected := []string{"application/servers/3/suspend"}; !reflect.DeepEqual(*calls, expected) {
+		t.Errorf("EvacuateNode() calls = %v, want %v", *calls, expected)
+	}
+}
+
+func TestEvacuateNodeStop(t *testing.T) {
+	pterodactylServer, calls := evacuationPanel(t)
+
+	results, err := EvacuateNode(pterodactylServer, 1, EvacuationOptions{
+		Action:       EvacuateStop,
+		ClientServer: pterodactylServer,
+		Shutdown:     ShutdownOptions{Warnings: []ShutdownWarning{{Before: time.Minute, Command: "say stopping"}}},
+	})
+	if err != nil {
+		t.Fatalf("EvacuateNode() error = %s", err)
+	}
+
+	for _, result := range results {
+		if result.Outcome != ShutdownStopped || result.Err != nil {
+			t.Errorf("EvacuateNode() result of '%s' = %+v, want stopped", result.Server.Attributes.Name, result)
+		}
+	}
+
+	// Every server is warned once before the first one is stopped
+	expected := []string{
+		"client/servers/lobby-uuid/command say stopping",
+		"client/servers/survival-uuid/command say stopping",
+		"client/servers/lobby-uuid/power stop",
+		"client/servers/survival-uuid/power stop",
+	}
+	if !reflect.DeepEqual(*calls, expected) {
+		t.Errorf("EvacuateNode() calls = %v, want %v", *calls, expected)
+	}
+}
+
+func TestEvacuateNodeTransfer(t *testing.T) {
+	pterodactylServer, _ := evacuationPanel(t)
+
+	var transfers []string
+	results, err := EvacuateNode(pterodactylServer, 1, EvacuationOptions{
+		Action: EvacuateSuspend,
+		Transfer: func(server ApplicationServer, target DeploymentTarget) error {
+			transfers = append(transfers, fmt.Sprintf("%s -> %d:%d", server.Attributes.Name, target.Node.Attributes.ID, target.Allocation.Attributes.Port))
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("EvacuateNode() error = %s", err)
+	}
+
+	// Node 1 has more room but is the node being evacuated
+	if expected := []string{"lobby -> 2:25565", "survival -> 2:25566"}; !reflect.DeepEqual(transfers, expected) {
+		t.Errorf("EvacuateNode() transfers = %v, want %v", transfers, expected)
+	}
+	for _, result := range results {
+		if result.Target == nil || result.Target.Node.Attributes.ID != 2 {
+			t.Errorf("EvacuateNode() target of '%s' = %+v, want node 2", result.Server.Attributes.Name, result.Target)
+		}
+	}
+}
+
+func TestEvacuateNodeValidation(t *testing.T) {
+	pterodactylServer, calls := evacuationPanel(t)
+
+	tests := map[string]EvacuationOptions{
+		"unknown action":       {Action: "drain"},
+		"stop without client":  {Action: EvacuateStop},
+		"warnings and suspend": {Action: EvacuateSuspend, Shutdown: ShutdownOptions{Warnings: []ShutdownWarning{{Command: "say stopping"}}}},
+	}
+
+	for name, options := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := EvacuateNode(pterodactylServer, 1, options)
+			if err == nil {
+				t.Error("EvacuateNode() error = nil, want an error")
+			}
+		})
+	}
+
+	if len(*calls) != 0 {
+		t.Errorf("EvacuateNode() sent %v with invalid options, want nothing", *calls)
+	}
+}
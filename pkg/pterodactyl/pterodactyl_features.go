@@ -0,0 +1,103 @@
+package pterodactyl
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+type Feature string
+
+const (
+	FeatureBackups        Feature = "backups"
+	FeatureSchedules      Feature = "schedules"
+	FeatureSubusers       Feature = "subusers"
+	FeatureStartup        Feature = "startup"
+	FeatureFiles          Feature = "files"
+	FeatureNetwork        Feature = "network"
+	FeatureResources      Feature = "resources"
+	FeatureApplicationApi Feature = "application_api"
+)
+
+var ErrFeatureUnsupported = errors.New("feature is not supported by the panel")
+
+type featureProbe struct {
+	feature  Feature
+	endpoint string
+	// serverPath is appended to the server the features are detected with, when set
+	serverPath []string
+}
+
+var featureProbes = []featureProbe{
+	{FeatureBackups, ApiEndpointServer, []string{ApiEndpointBackups}},
+	{FeatureSchedules, ApiEndpointServer, []string{"schedules"}},
+	{FeatureSubusers, ApiEndpointServer, []string{"users"}},
+	{FeatureStartup, ApiEndpointServer, []string{"startup"}},
+	{FeatureFiles, ApiEndpointServer, []string{ApiEndpointFiles, "list"}},
+	{FeatureNetwork, ApiEndpointServer, []string{"network", ApiEndpointAllocations}},
+	{FeatureResources, ApiEndpointServer, []string{ApiEndpointResources}},
+	{FeatureApplicationApi, ApiEndpointNodes, nil},
+}
+
+// Supports reports whether the panel is known to offer a feature. Features that were neither
+// declared nor detected are assumed to be supported, as they are on a stock panel.
+func (pterodactylServer PterodactylServer) Supports(feature Feature) bool {
+	supported, ok := pterodactylServer.Features[feature]
+	return !ok || supported
+}
+
+func requireFeature(pterodactylServer PterodactylServer, feature Feature) error {
+	if !pterodactylServer.Supports(feature) {
+		return fmt.Errorf("%w: %s", ErrFeatureUnsupported, feature)
+	}
+	return nil
+}
+
+func isMissingEndpoint(err error) bool {
+	return IsStatusCode(err, http.StatusNotFound) || IsStatusCode(err, http.StatusMethodNotAllowed) || IsStatusCode(err, http.StatusNotImplemented)
+}
+
+// DetectFeatures probes the endpoints behind each feature using a server the API key can
+// access. Declared features are kept as they are, the result can be assigned to Features.
+// Features the key is not allowed to probe, such as the backups of a subuser without the
+// backup permissions, are left out and so assumed to be supported.
+func DetectFeatures(pterodactylServer PterodactylServer, server Server) (map[Feature]bool, error) {
+	features := map[Feature]bool{}
+	for feature, supported := range pterodactylServer.Features {
+		features[feature] = supported
+	}
+
+	query := url.Values{}
+	query.Set("per_page", "1")
+
+	for _, probe := range featureProbes {
+		if _, ok := features[probe.feature]; ok {
+			continue
+		}
+
+		var subPaths []string
+		if probe.serverPath != nil {
+			subPaths = append([]string{server.Attributes.UUID}, probe.serverPath...)
+		}
+
+		var response struct{}
+		err := callApiWithQuery(&response, pterodactylServer, http.MethodGet, probe.endpoint, subPaths, query, nil)
+		switch {
+		case err == nil:
+			features[probe.feature] = true
+		case isMissingEndpoint(err):
+			features[probe.feature] = false
+		case IsStatusCode(err, http.StatusUnauthorized) || IsStatusCode(err, http.StatusForbidden):
+			// Client API keys are rejected by the application API, client endpoints only
+			// tell the key lacks a permission
+			if probe.feature == FeatureApplicationApi {
+				features[probe.feature] = false
+			}
+		default:
+			return nil, err
+		}
+	}
+
+	return features, nil
+}
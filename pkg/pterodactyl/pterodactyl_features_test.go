@@ -0,0 +1,60 @@
+package pterodactyl
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDetectFeatures(t *testing.T) {
+	panel := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/backups"), strings.HasPrefix(r.URL.Path, "/api/application/"):
+			w.WriteHeader(http.StatusForbidden)
+		case strings.HasSuffix(r.URL.Path, "/schedules"):
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			fmt.Fprint(w, `{"data": []}`)
+		}
+	}))
+	defer panel.Close()
+
+	pterodactylServer := PterodactylServer{Url: panel.URL, Features: map[Feature]bool{FeatureFiles: false}}
+	server := decodeServer(t, `{"attributes": {"uuid": "c9b1d8e2-0000"}}`)
+
+	features, err := DetectFeatures(pterodactylServer, server)
+	if err != nil {
+		t.Fatalf("DetectFeatures() error = %s", err)
+	}
+
+	expected := map[Feature]bool{
+		FeatureSchedules:      false,
+		FeatureSubusers:       true,
+		FeatureStartup:        true,
+		FeatureFiles:          false,
+		FeatureNetwork:        true,
+		FeatureResources:      true,
+		FeatureApplicationApi: false,
+	}
+	if !reflect.DeepEqual(features, expected) {
+		t.Errorf("DetectFeatures() = %v, want %v", features, expected)
+	}
+
+	pterodactylServer.Features = features
+	if !pterodactylServer.Supports(FeatureBackups) {
+		t.Error("Supports() of a feature the key may not probe = false, want true")
+	}
+}
+
+func TestStartServersInOrderRequiresResources(t *testing.T) {
+	pterodactylServer := PterodactylServer{Features: map[Feature]bool{FeatureResources: false}}
+
+	_, err := StartServersInOrder(pterodactylServer, []StartupUnit{startupUnit(t, "lobby")}, StartupOptions{})
+	if !errors.Is(err, ErrFeatureUnsupported) {
+		t.Errorf("StartServersInOrder() error = %v, want %v", err, ErrFeatureUnsupported)
+	}
+}
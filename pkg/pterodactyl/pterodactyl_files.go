@@ -0,0 +1,108 @@
+package pterodactyl
+
+import (
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	ApiEndpointFiles string = "files"
+)
+
+func GetServerFileDownloadUrl(pterodactylServer PterodactylServer, server Server, file string) (string, error) {
+	if err := requireFeature(pterodactylServer, FeatureFiles); err != nil {
+		return "", err
+	}
+
+	var downloadUrl ItemResponse[BackupUrl]
+	query := url.Values{}
+	query.Set("file", file)
+
+	err := callApiWithQuery(&downloadUrl, pterodactylServer, http.MethodGet, ApiEndpointServer, []string{server.Attributes.UUID, ApiEndpointFiles, "download"}, query, nil)
+	if err != nil {
+		return "", err
+	}
+
+	return downloadUrl.Data.Attributes.URL, nil
+}
+
+func DownloadServerFile(pterodactylServer PterodactylServer, server Server, file string, destination string, options ...DownloadOption) (*os.File, error) {
+	fetchUrl := func() (string, error) {
+		return GetServerFileDownloadUrl(pterodactylServer, server, file)
+	}
+
+	return downloadToFile(pterodactylServer, fetchUrl, destination, options)
+}
+
+func GetServerFileUploadUrl(pterodactylServer PterodactylServer, server Server) (string, error) {
+	if err := requireFeature(pterodactylServer, FeatureFiles); err != nil {
+		return "", err
+	}
+
+	var uploadUrl ItemResponse[BackupUrl]
+	err := callApi(&uploadUrl, pterodactylServer, http.MethodGet, ApiEndpointServer, []string{server.Attributes.UUID, ApiEndpointFiles, "upload"}, nil)
+	if err != nil {
+		return "", err
+	}
+
+	return uploadUrl.Data.Attributes.URL, nil
+}
+
+func UploadServerFile(pterodactylServer PterodactylServer, server Server, directory string, name string, content io.Reader) error {
+	uploadUrl, err := GetServerFileUploadUrl(pterodactylServer, server)
+	if err != nil {
+		return err
+	}
+
+	target, err := url.Parse(uploadUrl)
+	if err != nil {
+		return err
+	}
+	query := target.Query()
+	query.Set("directory", directory)
+	target.RawQuery = query.Encode()
+
+	// Stream the multipart body so large archives are never held in memory
+	reader, writer := io.Pipe()
+	form := multipart.NewWriter(writer)
+	go func() {
+		part, err := form.CreateFormFile("files", name)
+		if err == nil {
+			_, err = io.Copy(part, content)
+		}
+		if err == nil {
+			err = form.Close()
+		}
+		writer.CloseWithError(err)
+	}()
+
+	log.Trace(fmt.Sprintf("UploadServerFile -> Uploading '%s' to '%s'", name, directory))
+	req, _ := http.NewRequest(http.MethodPost, target.String(), reader)
+	req.Header.Add("Content-Type", form.FormDataContentType())
+	res, err := pterodactylServer.doRequest(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK && res.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("upload failed with status code %d", res.StatusCode)
+	}
+
+	return nil
+}
+
+func DecompressServerFile(pterodactylServer PterodactylServer, server Server, root string, file string) error {
+	if err := requireFeature(pterodactylServer, FeatureFiles); err != nil {
+		return err
+	}
+
+	var response struct{}
+	return callApi(&response, pterodactylServer, http.MethodPost, ApiEndpointServer, []string{server.Attributes.UUID, ApiEndpointFiles, "decompress"}, map[string]string{"root": root, "file": file})
+}
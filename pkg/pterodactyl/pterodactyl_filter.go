@@ -0,0 +1,57 @@
+package pterodactyl
+
+import (
+	"strings"
+)
+
+// ServerFilter matches servers on every criteria that is set. Egg names are only known for
+// servers fetched with the egg relationship, as GetAllServers does.
+type ServerFilter struct {
+	Node        string
+	Egg         string
+	Description string
+	Suspended   *bool
+	Installing  *bool
+	// OwnedByMe matches on whether the user of the API key owns the server rather than being
+	// a subuser. The client API does not tell who else owns a server.
+	OwnedByMe *bool
+}
+
+func BoolPointer(value bool) *bool {
+	return &value
+}
+
+func containsFold(value string, substring string) bool {
+	return strings.Contains(strings.ToLower(value), strings.ToLower(substring))
+}
+
+func (filter ServerFilter) Matches(server Server) bool {
+	attributes := server.Attributes
+
+	switch {
+	case filter.Node != "" && attributes.Node != filter.Node:
+		return false
+	case filter.Egg != "" && !containsFold(attributes.Relationships.Egg.Attributes.Name, filter.Egg):
+		return false
+	case filter.Description != "" && !containsFold(attributes.Description, filter.Description):
+		return false
+	case filter.Suspended != nil && attributes.IsSuspended != *filter.Suspended:
+		return false
+	case filter.Installing != nil && attributes.IsInstalling != *filter.Installing:
+		return false
+	case filter.OwnedByMe != nil && attributes.ServerOwner != *filter.OwnedByMe:
+		return false
+	}
+
+	return true
+}
+
+func (filter ServerFilter) Apply(servers []Server) []Server {
+	var matches []Server
+	for _, server := range servers {
+		if filter.Matches(server) {
+			matches = append(matches, server)
+		}
+	}
+	return matches
+}
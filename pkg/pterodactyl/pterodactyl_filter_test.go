@@ -0,0 +1,77 @@
+package pterodactyl
+
+import (
+	"reflect"
+	"testing"
+)
+
+func filterServers(t *testing.T) []Server {
+	t.Helper()
+
+	return []Server{
+		decodeServer(t, `{"attributes": {"name": "lobby", "node": "node-1", "description": "Lobby for the network", "server_owner": true,
+			"relationships": {"egg": {"attributes": {"name": "Paper"}}}}}`),
+		decodeServer(t, `{"attributes": {"name": "survival", "node": "node-2", "is_suspended": true,
+			"relationships": {"egg": {"attributes": {"name": "Forge Minecraft"}}}}}`),
+		decodeServer(t, `{"attributes": {"name": "proxy", "node": "node-1", "is_installing": true, "server_owner": true,
+			"relationships": {"egg": {"attributes": {"name": "Velocity"}}}}}`),
+	}
+}
+
+func TestServerFilterMatches(t *testing.T) {
+	lobby := filterServers(t)[0]
+
+	tests := map[string]struct {
+		filter   ServerFilter
+		expected bool
+	}{
+		"empty filter":        {filter: ServerFilter{}, expected: true},
+		"node":                {filter: ServerFilter{Node: "node-1"}, expected: true},
+		"other node":          {filter: ServerFilter{Node: "node-2"}, expected: false},
+		"egg substring":       {filter: ServerFilter{Egg: "pap"}, expected: true},
+		"description":         {filter: ServerFilter{Description: "NETWORK"}, expected: true},
+		"other description":   {filter: ServerFilter{Description: "survival"}, expected: false},
+		"not suspended":       {filter: ServerFilter{Suspended: BoolPointer(false)}, expected: true},
+		"suspended":           {filter: ServerFilter{Suspended: BoolPointer(true)}, expected: false},
+		"installing":          {filter: ServerFilter{Installing: BoolPointer(true)}, expected: false},
+		"owned by me":         {filter: ServerFilter{OwnedByMe: BoolPointer(true)}, expected: true},
+		"not owned by me":     {filter: ServerFilter{OwnedByMe: BoolPointer(false)}, expected: false},
+		"every criteria":      {filter: ServerFilter{Node: "node-1", Egg: "paper", Suspended: BoolPointer(false), OwnedByMe: BoolPointer(true)}, expected: true},
+		"one failed criteria": {filter: ServerFilter{Node: "node-1", Egg: "forge"}, expected: false},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if matches := test.filter.Matches(lobby); matches != test.expected {
+				t.Errorf("Matches() = %t, want %t", matches, test.expected)
+			}
+		})
+	}
+}
+
+func TestServerFilterApply(t *testing.T) {
+	servers := filterServers(t)
+
+	tests := map[string]struct {
+		filter   ServerFilter
+		expected []string
+	}{
+		"node":        {filter: ServerFilter{Node: "node-1"}, expected: []string{"lobby", "proxy"}},
+		"egg":         {filter: ServerFilter{Egg: "minecraft"}, expected: []string{"survival"}},
+		"not owned":   {filter: ServerFilter{OwnedByMe: BoolPointer(false)}, expected: []string{"survival"}},
+		"no match":    {filter: ServerFilter{Node: "node-3"}, expected: nil},
+		"operational": {filter: ServerFilter{Suspended: BoolPointer(false), Installing: BoolPointer(false)}, expected: []string{"lobby"}},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var names []string
+			for _, server := range test.filter.Apply(servers) {
+				names = append(names, server.Attributes.Name)
+			}
+			if !reflect.DeepEqual(names, test.expected) {
+				t.Errorf("Apply() = %v, want %v", names, test.expected)
+			}
+		})
+	}
+}
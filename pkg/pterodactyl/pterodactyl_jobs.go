@@ -0,0 +1,508 @@
+package pterodactyl
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+type JobState string
+
+const (
+	JobPending   JobState = "pending"
+	JobRunning   JobState = "running"
+	JobSucceeded JobState = "succeeded"
+	JobFailed    JobState = "failed"
+
+	JobKindBackup  string = "backup"
+	JobKindRestore string = "restore"
+	// JobKindTransfer has no default handler, the panel API cannot start transfers. Register
+	// one that starts the transfer the way the panel is set up for.
+	JobKindTransfer string = "transfer"
+)
+
+type Job struct {
+	ID        string            `json:"id"`
+	Panel     string            `json:"panel"`
+	ServerId  string            `json:"server_id"`
+	Kind      string            `json:"kind"`
+	Args      map[string]string `json:"args,omitempty"`
+	State     JobState          `json:"state"`
+	Attempts  int               `json:"attempts"`
+	LastError string            `json:"last_error,omitempty"`
+	CreatedAt time.Time         `json:"created_at"`
+	UpdatedAt time.Time         `json:"updated_at"`
+}
+
+// JobHandler runs a job. Stop is closed when the queue is closed, long running handlers
+// should return early so the job stays pending in the journal.
+type JobHandler func(pterodactylServer PterodactylServer, job Job, stop <-chan struct{}) error
+
+type JobQueueOptions struct {
+	// JournalPath enables the on-disk journal, jobs left pending or running are picked up again
+	// when a queue is created with the same journal and their panel is added.
+	JournalPath  string
+	MaxAttempts  int
+	RetryBackoff time.Duration
+	// MaxRetryBackoff caps the doubling retry backoff, defaults to 5 minutes.
+	MaxRetryBackoff time.Duration
+	// MinInterval is the pause between two jobs against the same panel.
+	MinInterval time.Duration
+}
+
+type JobQueue struct {
+	options JobQueueOptions
+
+	mutex    sync.Mutex
+	handlers map[string]JobHandler
+	panels   map[string]*jobPanel
+	jobs     map[string]*Job
+	journal  *os.File
+	closed   bool
+
+	stop      chan struct{}
+	waitGroup sync.WaitGroup
+}
+
+type jobPanel struct {
+	pterodactylServer PterodactylServer
+	pending           []*Job
+	wake              chan struct{}
+}
+
+func backupJobHandler(pterodactylServer PterodactylServer, job Job, stop <-chan struct{}) error {
+	server, err := GetServer(pterodactylServer, job.ServerId)
+	if err != nil {
+		return err
+	}
+
+	var name string
+	if pterodactylServer.BackupNameTemplate != "" {
+		name = RenderBackupName(pterodactylServer.BackupNameTemplate, server, job.Args["tag"], pterodactylServer.clock().Now())
+	}
+
+	backup, err := BackupServerWithName(pterodactylServer, server, name)
+	if err != nil {
+		return err
+	}
+
+	completed, err := WaitForBackup(pterodactylServer, server, backup.Attributes.UUID, WithStop(stop))
+	if err != nil {
+		return err
+	}
+	if !completed.Attributes.IsSuccessful {
+		return fmt.Errorf("backup '%s' of server '%s' failed", completed.Attributes.UUID, server.Attributes.Name)
+	}
+
+	return nil
+}
+
+// restoreJobHandler restores the backup in the "backup" argument, deleting the server files
+// first when "truncate" is "true". The job ends once the server is no longer restoring.
+func restoreJobHandler(pterodactylServer PterodactylServer, job Job, stop <-chan struct{}) error {
+	backupId := job.Args["backup"]
+	if backupId == "" {
+		return errors.New("restore job has no backup argument")
+	}
+
+	server, err := GetServer(pterodactylServer, job.ServerId)
+	if err != nil {
+		return err
+	}
+
+	err = RestoreServerBackup(pterodactylServer, server, backupId, job.Args["truncate"] == "true")
+	if err != nil {
+		return err
+	}
+
+	options := newWaitOptions(DefaultBackupPollInterval, []WaitOption{WithStop(stop)})
+	clock := pterodactylServer.clock()
+	for {
+		server, err = GetServer(pterodactylServer, job.ServerId)
+		if err != nil {
+			return err
+		}
+		if !server.IsRestoringBackup() {
+			return nil
+		}
+
+		log.Debugf("JobQueue -> Waiting for server '%s' to restore backup '%s'...", server.Attributes.Name, backupId)
+		if !options.sleep(clock) {
+			return ErrWaitStopped
+		}
+	}
+}
+
+func NewJobQueue(options JobQueueOptions) (*JobQueue, error) {
+	if options.MaxAttempts < 1 {
+		options.MaxAttempts = 5
+	}
+	if options.RetryBackoff <= 0 {
+		options.RetryBackoff = 10 * time.Second
+	}
+	if options.MaxRetryBackoff <= 0 {
+		options.MaxRetryBackoff = 5 * time.Minute
+	}
+
+	queue := &JobQueue{
+		options:  options,
+		handlers: map[string]JobHandler{JobKindBackup: backupJobHandler, JobKindRestore: restoreJobHandler},
+		panels:   map[string]*jobPanel{},
+		jobs:     map[string]*Job{},
+		stop:     make(chan struct{}),
+	}
+
+	if options.JournalPath != "" {
+		err := queue.replayJournal()
+		if err != nil {
+			return nil, err
+		}
+
+		err = queue.compactJournal()
+		if err != nil {
+			return nil, err
+		}
+
+		queue.journal, err = os.OpenFile(options.JournalPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return queue, nil
+}
+
+func (queue *JobQueue) replayJournal() error {
+	file, err := os.Open(queue.options.JournalPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var job Job
+		err = json.Unmarshal(scanner.Bytes(), &job)
+		if err != nil {
+			// A partially written last line is expected after a crash
+			log.Warnf("JobQueue -> Skipping unreadable journal entry: %s", err)
+			continue
+		}
+
+		if job.State == JobRunning {
+			job.State = JobPending
+		}
+		queue.jobs[job.ID] = &job
+	}
+
+	return scanner.Err()
+}
+
+// compactJournal rewrites the journal with the jobs left to run, finished jobs of previous
+// runs are dropped so the journal does not grow forever.
+func (queue *JobQueue) compactJournal() error {
+	var unfinished []*Job
+	for id, job := range queue.jobs {
+		if job.State == JobSucceeded || job.State == JobFailed {
+			delete(queue.jobs, id)
+			continue
+		}
+		unfinished = append(unfinished, job)
+	}
+	sort.SliceStable(unfinished, func(i, j int) bool {
+		return unfinished[i].CreatedAt.Before(unfinished[j].CreatedAt)
+	})
+
+	var content []byte
+	for _, job := range unfinished {
+		line, err := json.Marshal(job)
+		if err != nil {
+			return err
+		}
+		content = append(append(content, line...), '\n')
+	}
+
+	temporary := fmt.Sprintf("%s.tmp", queue.options.JournalPath)
+	err := os.WriteFile(temporary, content, 0600)
+	if err != nil {
+		return err
+	}
+	return os.Rename(temporary, queue.options.JournalPath)
+}
+
+// record must be called with the queue mutex held.
+func (queue *JobQueue) record(panel *jobPanel, job *Job) {
+	job.UpdatedAt = panel.pterodactylServer.clock().Now()
+	if queue.journal == nil {
+		return
+	}
+
+	line, err := json.Marshal(job)
+	if err == nil {
+		_, err = queue.journal.Write(append(line, '\n'))
+	}
+	if err != nil {
+		log.Errorf("JobQueue -> Failed to journal job '%s': %s", job.ID, err)
+	}
+}
+
+// RegisterHandler sets the handler of a job kind, pending jobs of that kind replayed from the
+// journal start running once it is registered.
+func (queue *JobQueue) RegisterHandler(kind string, handler JobHandler) {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+
+	queue.handlers[kind] = handler
+	for _, panel := range queue.panels {
+		panel.notify()
+	}
+}
+
+// AddPanel starts the worker for a panel. Jobs are executed one at a time per panel, so
+// operations against the same panel and server never overlap. Jobs of a kind without a
+// handler stay pending until one is registered.
+func (queue *JobQueue) AddPanel(pterodactylServer PterodactylServer) error {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+
+	if queue.closed {
+		return errors.New("job queue is closed")
+	}
+	if _, ok := queue.panels[pterodactylServer.Name]; ok {
+		return fmt.Errorf("panel '%s' is already added", pterodactylServer.Name)
+	}
+
+	panel := &jobPanel{pterodactylServer: pterodactylServer, wake: make(chan struct{}, 1)}
+	for _, job := range queue.jobs {
+		if job.Panel == pterodactylServer.Name && job.State == JobPending {
+			panel.pending = append(panel.pending, job)
+		}
+	}
+	sort.SliceStable(panel.pending, func(i, j int) bool {
+		return panel.pending[i].CreatedAt.Before(panel.pending[j].CreatedAt)
+	})
+	queue.panels[pterodactylServer.Name] = panel
+
+	queue.waitGroup.Add(1)
+	go queue.work(panel)
+	panel.notify()
+
+	return nil
+}
+
+func (panel *jobPanel) notify() {
+	select {
+	case panel.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (queue *JobQueue) Enqueue(panelName string, serverId string, kind string, args map[string]string) (Job, error) {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+
+	if queue.closed {
+		return Job{}, errors.New("job queue is closed")
+	}
+	panel, ok := queue.panels[panelName]
+	if !ok {
+		return Job{}, fmt.Errorf("panel '%s' is not added to the job queue", panelName)
+	}
+	if _, ok := queue.handlers[kind]; !ok {
+		return Job{}, fmt.Errorf("no handler registered for job kind '%s'", kind)
+	}
+
+	id := make([]byte, 8)
+	_, err := rand.Read(id)
+	if err != nil {
+		return Job{}, err
+	}
+
+	job := &Job{
+		ID:        hex.EncodeToString(id),
+		Panel:     panelName,
+		ServerId:  serverId,
+		Kind:      kind,
+		Args:      args,
+		State:     JobPending,
+		CreatedAt: panel.pterodactylServer.clock().Now(),
+	}
+	queue.jobs[job.ID] = job
+	queue.record(panel, job)
+
+	panel.pending = append(panel.pending, job)
+	panel.notify()
+
+	return *job, nil
+}
+
+func (queue *JobQueue) Job(id string) (Job, bool) {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+
+	job, ok := queue.jobs[id]
+	if !ok {
+		return Job{}, false
+	}
+	return *job, true
+}
+
+func (queue *JobQueue) Jobs() []Job {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+
+	var jobs []Job
+	for _, job := range queue.jobs {
+		jobs = append(jobs, *job)
+	}
+	return jobs
+}
+
+func (queue *JobQueue) sleep(panel *jobPanel, duration time.Duration) bool {
+	select {
+	case <-queue.stop:
+		return false
+	case <-panel.pterodactylServer.clock().After(duration):
+		return true
+	}
+}
+
+// next returns the oldest pending job that has a handler.
+func (queue *JobQueue) next(panel *jobPanel) (*Job, JobHandler) {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+
+	for i, job := range panel.pending {
+		handler, ok := queue.handlers[job.Kind]
+		if !ok {
+			continue
+		}
+
+		panel.pending = append(panel.pending[:i:i], panel.pending[i+1:]...)
+		job.State = JobRunning
+		queue.record(panel, job)
+		return job, handler
+	}
+
+	return nil, nil
+}
+
+func (queue *JobQueue) work(panel *jobPanel) {
+	defer queue.waitGroup.Done()
+
+	for {
+		select {
+		case <-queue.stop:
+			return
+		default:
+		}
+
+		job, handler := queue.next(panel)
+		if job == nil {
+			select {
+			case <-queue.stop:
+				return
+			case <-panel.wake:
+				continue
+			}
+		}
+
+		if !queue.run(panel, job, handler) {
+			return
+		}
+
+		if queue.options.MinInterval > 0 && !queue.sleep(panel, queue.options.MinInterval) {
+			return
+		}
+	}
+}
+
+// run executes a job until it succeeds, fails permanently or the queue is closed, in which
+// case false is returned and the job stays pending in the journal.
+func (queue *JobQueue) run(panel *jobPanel, job *Job, handler JobHandler) bool {
+	backoff := queue.options.RetryBackoff
+
+	for {
+		queue.mutex.Lock()
+		job.Attempts++
+		snapshot := *job
+		queue.mutex.Unlock()
+
+		log.Debugf("JobQueue -> Running %s job '%s' for server '%s' on panel '%s' (attempt %d)", job.Kind, job.ID, job.ServerId, job.Panel, snapshot.Attempts)
+		err := handler(panel.pterodactylServer, snapshot, queue.stop)
+
+		queue.mutex.Lock()
+		if err != nil && queue.closed {
+			// The handler was interrupted, the job runs again once the queue is reopened
+			job.State = JobPending
+			queue.record(panel, job)
+			queue.mutex.Unlock()
+			return false
+		}
+		if err == nil {
+			job.State = JobSucceeded
+			job.LastError = ""
+			queue.record(panel, job)
+			queue.mutex.Unlock()
+			return true
+		}
+
+		job.LastError = err.Error()
+		retry := IsTransientError(err) && job.Attempts < queue.options.MaxAttempts
+		if !retry {
+			job.State = JobFailed
+		}
+		queue.record(panel, job)
+		queue.mutex.Unlock()
+
+		if !retry {
+			log.Errorf("JobQueue -> %s job '%s' failed: %s", job.Kind, job.ID, err)
+			return true
+		}
+
+		log.Warnf("JobQueue -> %s job '%s' failed, retrying in %s: %s", job.Kind, job.ID, backoff, err)
+		if !queue.sleep(panel, backoff) {
+			queue.mutex.Lock()
+			job.State = JobPending
+			queue.record(panel, job)
+			queue.mutex.Unlock()
+			return false
+		}
+		backoff *= 2
+		if backoff > queue.options.MaxRetryBackoff {
+			backoff = queue.options.MaxRetryBackoff
+		}
+	}
+}
+
+// Close asks the running handlers to stop, waits for the workers to return and closes the
+// journal.
+func (queue *JobQueue) Close() error {
+	queue.mutex.Lock()
+	if queue.closed {
+		queue.mutex.Unlock()
+		return nil
+	}
+	queue.closed = true
+	close(queue.stop)
+	queue.mutex.Unlock()
+
+	queue.waitGroup.Wait()
+
+	if queue.journal != nil {
+		return queue.journal.Close()
+	}
+	return nil
+}
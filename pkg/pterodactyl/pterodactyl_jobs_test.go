@@ -0,0 +1,313 @@
+package pterodactyl
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestJobQueueUsesPanelClock(t *testing.T) {
+	queue, err := NewJobQueue(JobQueueOptions{})
+	if err != nil {
+		t.Fatalf("NewJobQueue() error = %s", err)
+	}
+	defer queue.Close()
+
+	clock := newFakeClock()
+	done := make(chan struct{})
+	queue.RegisterHandler("noop", func(pterodactylServer PterodactylServer, job Job, stop <-chan struct{}) error {
+		close(done)
+		return nil
+	})
+
+	err = queue.AddPanel(PterodactylServer{Name: "main", Clock: clock})
+	if err != nil {
+		t.Fatalf("AddPanel() error = %s", err)
+	}
+
+	job, err := queue.Enqueue("main", "c9b1d8e2", "noop", nil)
+	if err != nil {
+		t.Fatalf("Enqueue() error = %s", err)
+	}
+	<-done
+
+	if !job.CreatedAt.Equal(clock.Now()) || !job.UpdatedAt.Equal(clock.Now()) {
+		t.Errorf("Enqueue() created at %s, updated at %s, want the panel clock time %s", job.CreatedAt, job.UpdatedAt, clock.Now())
+	}
+}
+
+func writeJournal(t *testing.T, jobs ...Job) string {
+	t.Helper()
+
+	var content []byte
+	for _, job := range jobs {
+		line, err := json.Marshal(job)
+		if err != nil {
+			t.Fatalf("json.Marshal() error = %s", err)
+		}
+		content = append(append(content, line...), '\n')
+	}
+
+	path := filepath.Join(t.TempDir(), "jobs.journal")
+	err := os.WriteFile(path, append(content, `{"id": "trunc`...), 0600)
+	if err != nil {
+		t.Fatalf("os.WriteFile() error = %s", err)
+	}
+	return path
+}
+
+// waitForJob polls the queue until the job reaches the state, workers record it after the
+// handler returned.
+func waitForJob(t *testing.T, queue *JobQueue, id string, state JobState) Job {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		job, _ := queue.Job(id)
+		if job.State == state {
+			return job
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("job '%s' is %s, want %s", id, job.State, state)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func transientError() error {
+	return &ApiRequestError{StatusCode: http.StatusServiceUnavailable}
+}
+
+func TestJobQueueReplaysJournal(t *testing.T) {
+	created := newFakeClock().Now()
+	path := writeJournal(t,
+		Job{ID: "running", Panel: "main", Kind: JobKindBackup, State: JobRunning, CreatedAt: created},
+		Job{ID: "succeeded", Panel: "main", Kind: JobKindBackup, State: JobSucceeded, CreatedAt: created},
+		Job{ID: "failed", Panel: "main", Kind: JobKindBackup, State: JobFailed, CreatedAt: created},
+		Job{ID: "pending", Panel: "main", Kind: JobKindBackup, State: JobPending, CreatedAt: created.Add(time.Second)},
+		// A later entry of the same job replaces the earlier ones
+		Job{ID: "succeeded", Panel: "main", Kind: JobKindBackup, State: JobRunning, CreatedAt: created},
+		Job{ID: "succeeded", Panel: "main", Kind: JobKindBackup, State: JobSucceeded, CreatedAt: created},
+	)
+
+	queue, err := NewJobQueue(JobQueueOptions{JournalPath: path})
+	if err != nil {
+		t.Fatalf("NewJobQueue() error = %s", err)
+	}
+	defer queue.Close()
+
+	states := map[string]JobState{}
+	for _, job := range queue.Jobs() {
+		states[job.ID] = job.State
+	}
+	if expected := map[string]JobState{"running": JobPending, "pending": JobPending}; !reflect.DeepEqual(states, expected) {
+		t.Errorf("Jobs() = %v, want %v", states, expected)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("os.ReadFile() error = %s", err)
+	}
+	var ids []string
+	for _, line := range strings.Split(strings.TrimSpace(string(content)), "\n") {
+		var job Job
+		if err := json.Unmarshal([]byte(line), &job); err != nil {
+			t.Fatalf("compacted journal has an unreadable line %q: %s", line, err)
+		}
+		ids = append(ids, job.ID)
+	}
+	if expected := []string{"running", "pending"}; !reflect.DeepEqual(ids, expected) {
+		t.Errorf("compacted journal = %v, want %v", ids, expected)
+	}
+}
+
+func TestJobQueueRetriesTransientErrors(t *testing.T) {
+	queue, err := NewJobQueue(JobQueueOptions{MaxAttempts: 5, RetryBackoff: 10 * time.Second, MaxRetryBackoff: 25 * time.Second})
+	if err != nil {
+		t.Fatalf("NewJobQueue() error = %s", err)
+	}
+	defer queue.Close()
+
+	var attempts int
+	queue.RegisterHandler("flaky", func(pterodactylServer PterodactylServer, job Job, stop <-chan struct{}) error {
+		attempts++
+		if attempts < 4 {
+			return transientError()
+		}
+		return nil
+	})
+
+	clock := newFakeClock()
+	err = queue.AddPanel(PterodactylServer{Name: "main", Clock: clock})
+	if err != nil {
+		t.Fatalf("AddPanel() error = %s", err)
+	}
+
+	job, err := queue.Enqueue("main", "c9b1d8e2", "flaky", nil)
+	if err != nil {
+		t.Fatalf("Enqueue() error = %s", err)
+	}
+	job = waitForJob(t, queue, job.ID, JobSucceeded)
+
+	if job.Attempts != 4 || job.LastError != "" {
+		t.Errorf("job attempts = %d, last error = %q, want 4 attempts and no error", job.Attempts, job.LastError)
+	}
+	if expected := []time.Duration{10 * time.Second, 20 * time.Second, 25 * time.Second}; !reflect.DeepEqual(clock.waits, expected) {
+		t.Errorf("retry backoffs = %v, want %v", clock.waits, expected)
+	}
+}
+
+func TestJobQueueFailsAfterMaxAttempts(t *testing.T) {
+	queue, err := NewJobQueue(JobQueueOptions{MaxAttempts: 3})
+	if err != nil {
+		t.Fatalf("NewJobQueue() error = %s", err)
+	}
+	defer queue.Close()
+
+	queue.RegisterHandler("down", func(pterodactylServer PterodactylServer, job Job, stop <-chan struct{}) error {
+		return transientError()
+	})
+	queue.RegisterHandler("broken", func(pterodactylServer PterodactylServer, job Job, stop <-chan struct{}) error {
+		return errors.New("server not found")
+	})
+
+	err = queue.AddPanel(PterodactylServer{Name: "main", Clock: newFakeClock()})
+	if err != nil {
+		t.Fatalf("AddPanel() error = %s", err)
+	}
+
+	tests := map[string]int{"down": 3, "broken": 1}
+	for kind, attempts := range tests {
+		job, err := queue.Enqueue("main", "c9b1d8e2", kind, nil)
+		if err != nil {
+			t.Fatalf("Enqueue() error = %s", err)
+		}
+
+		job = waitForJob(t, queue, job.ID, JobFailed)
+		if job.Attempts != attempts || job.LastError == "" {
+			t.Errorf("%s job attempts = %d, last error = %q, want %d attempts and the error", kind, job.Attempts, job.LastError, attempts)
+		}
+	}
+}
+
+func TestJobQueueCloseKeepsInterruptedJobs(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jobs.journal")
+	queue, err := NewJobQueue(JobQueueOptions{JournalPath: path})
+	if err != nil {
+		t.Fatalf("NewJobQueue() error = %s", err)
+	}
+
+	started := make(chan struct{})
+	queue.RegisterHandler("long", func(pterodactylServer PterodactylServer, job Job, stop <-chan struct{}) error {
+		close(started)
+		<-stop
+		return ErrWaitStopped
+	})
+
+	err = queue.AddPanel(PterodactylServer{Name: "main", Clock: newFakeClock()})
+	if err != nil {
+		t.Fatalf("AddPanel() error = %s", err)
+	}
+	job, err := queue.Enqueue("main", "c9b1d8e2", "long", nil)
+	if err != nil {
+		t.Fatalf("Enqueue() error = %s", err)
+	}
+
+	<-started
+	err = queue.Close()
+	if err != nil {
+		t.Fatalf("Close() error = %s", err)
+	}
+	if job, _ := queue.Job(job.ID); job.State != JobPending {
+		t.Errorf("interrupted job is %s, want %s", job.State, JobPending)
+	}
+
+	reopened, err := NewJobQueue(JobQueueOptions{JournalPath: path})
+	if err != nil {
+		t.Fatalf("NewJobQueue() error = %s", err)
+	}
+	defer reopened.Close()
+	if job, ok := reopened.Job(job.ID); !ok || job.State != JobPending {
+		t.Errorf("replayed interrupted job = %+v, want it pending", job)
+	}
+}
+
+func TestJobQueueWaitsForHandler(t *testing.T) {
+	path := writeJournal(t, Job{ID: "transfer", Panel: "main", ServerId: "c9b1d8e2", Kind: JobKindTransfer, State: JobPending})
+
+	queue, err := NewJobQueue(JobQueueOptions{JournalPath: path})
+	if err != nil {
+		t.Fatalf("NewJobQueue() error = %s", err)
+	}
+	defer queue.Close()
+
+	err = queue.AddPanel(PterodactylServer{Name: "main", Clock: newFakeClock()})
+	if err != nil {
+		t.Fatalf("AddPanel() error = %s", err)
+	}
+
+	// The transfer job has no handler yet and must not fail meanwhile
+	time.Sleep(10 * time.Millisecond)
+	if job, _ := queue.Job("transfer"); job.State != JobPending || job.Attempts != 0 {
+		t.Fatalf("job without a handler = %+v, want it pending", job)
+	}
+
+	queue.RegisterHandler(JobKindTransfer, func(pterodactylServer PterodactylServer, job Job, stop <-chan struct{}) error {
+		return nil
+	})
+	waitForJob(t, queue, "transfer", JobSucceeded)
+}
+
+func TestRestoreJobHandler(t *testing.T) {
+	var mutex sync.Mutex
+	var restores []string
+	statuses := []string{`"restoring_backup"`, `"restoring_backup"`, `null`}
+	panel := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		if strings.HasSuffix(r.URL.Path, "/restore") {
+			body, _ := io.ReadAll(r.Body)
+			restores = append(restores, fmt.Sprintf("%s %s", r.URL.Path, body))
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		status := `null`
+		if len(restores) > 0 {
+			status, statuses = statuses[0], statuses[1:]
+		}
+		fmt.Fprintf(w, `{"attributes": {"uuid": "c9b1d8e2-0000", "name": "lobby", "status": %s}}`, status)
+	}))
+	defer panel.Close()
+
+	clock := newFakeClock()
+	pterodactylServer := PterodactylServer{Url: panel.URL, Clock: clock}
+	job := Job{ServerId: "c9b1d8e2", Kind: JobKindRestore, Args: map[string]string{"backup": "904df120", "truncate": "true"}}
+
+	err := restoreJobHandler(pterodactylServer, job, nil)
+	if err != nil {
+		t.Fatalf("restoreJobHandler() error = %s", err)
+	}
+	if expected := []string{`/api/client/servers/c9b1d8e2-0000/backups/904df120/restore {"truncate":true}`}; !reflect.DeepEqual(restores, expected) {
+		t.Errorf("restoreJobHandler() restores = %v, want %v", restores, expected)
+	}
+	if len(clock.waits) != 2 {
+		t.Errorf("restoreJobHandler() waited %d times, want 2 while the server was restoring", len(clock.waits))
+	}
+
+	err = restoreJobHandler(pterodactylServer, Job{ServerId: "c9b1d8e2", Kind: JobKindRestore}, nil)
+	if err == nil {
+		t.Error("restoreJobHandler() without a backup error = nil, want an error")
+	}
+}
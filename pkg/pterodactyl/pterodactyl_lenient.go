@@ -0,0 +1,251 @@
+package pterodactyl
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var jsonUnmarshaler = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// decodeLenient decodes data into v, and when a value does not match the type of its field
+// decodes it again after converting the variations panels are known to send: numbers and
+// booleans as strings, booleans as 0 or 1, and PHP arrays serialized as objects or empty
+// objects serialized as empty arrays. Empty strings are not taken for a zero number or
+// false, they still fail the decoding.
+func decodeLenient(data []byte, v any) error {
+	err := json.Unmarshal(data, v)
+	var typeError *json.UnmarshalTypeError
+	if !errors.As(err, &typeError) {
+		return err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var raw any
+	if decoder.Decode(&raw) != nil {
+		return err
+	}
+
+	coerced, marshalErr := json.Marshal(coerceJSON(raw, reflect.TypeOf(v)))
+	if marshalErr != nil {
+		return err
+	}
+
+	// Drop what the failed attempt decoded so the second one starts from a zero value
+	target := reflect.ValueOf(v)
+	for target.Kind() == reflect.Pointer && !target.IsNil() {
+		target = target.Elem()
+	}
+	if target.CanSet() {
+		target.Set(reflect.Zero(target.Type()))
+	}
+
+	return json.Unmarshal(coerced, v)
+}
+
+func coerceJSON(value any, t reflect.Type) any {
+	if value == nil || t.Implements(jsonUnmarshaler) || reflect.PointerTo(t).Implements(jsonUnmarshaler) {
+		return value
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return coerceJSON(value, t.Elem())
+	case reflect.Bool:
+		return coerceBool(value)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return coerceNumber(value, t)
+	case reflect.String:
+		switch value := value.(type) {
+		case json.Number:
+			return string(value)
+		case bool:
+			return strconv.FormatBool(value)
+		}
+	case reflect.Slice, reflect.Array:
+		return coerceList(value, t)
+	case reflect.Map:
+		return coerceMap(value, t)
+	case reflect.Struct:
+		return coerceStruct(value, t)
+	}
+
+	return value
+}
+
+func coerceBool(value any) any {
+	switch value := value.(type) {
+	case json.Number:
+		number, err := value.Float64()
+		if err == nil {
+			return number != 0
+		}
+	case string:
+		switch strings.ToLower(strings.TrimSpace(value)) {
+		case "1", "true", "yes", "on":
+			return true
+		case "0", "false", "no", "off":
+			return false
+		}
+	}
+	return value
+}
+
+func coerceNumber(value any, t reflect.Type) any {
+	var number float64
+	switch value := value.(type) {
+	case json.Number:
+		parsed, err := value.Float64()
+		if err != nil {
+			return value
+		}
+		number = parsed
+	case string:
+		parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return value
+		}
+		number = parsed
+	case bool:
+		if value {
+			number = 1
+		}
+	default:
+		return value
+	}
+
+	if t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64 {
+		return json.Number(strconv.FormatFloat(number, 'f', -1, 64))
+	}
+	if number != math.Trunc(number) {
+		return value
+	}
+	return json.Number(strconv.FormatFloat(number, 'f', 0, 64))
+}
+
+func coerceList(value any, t reflect.Type) any {
+	if t.Elem().Kind() == reflect.Uint8 {
+		return value
+	}
+
+	var items []any
+	switch value := value.(type) {
+	case []any:
+		items = value
+	case map[string]any:
+		// PHP serializes arrays with missing indexes as objects
+		keys := make([]string, 0, len(value))
+		for key := range value {
+			keys = append(keys, key)
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			left, leftErr := strconv.Atoi(keys[i])
+			right, rightErr := strconv.Atoi(keys[j])
+			if leftErr == nil && rightErr == nil {
+				return left < right
+			}
+			return keys[i] < keys[j]
+		})
+		for _, key := range keys {
+			items = append(items, value[key])
+		}
+	case string:
+		// Empty values sent instead of an empty list
+		if value == "" {
+			return nil
+		}
+		return value
+	case bool:
+		if !value {
+			return nil
+		}
+		return value
+	default:
+		return value
+	}
+
+	coerced := make([]any, len(items))
+	for i, item := range items {
+		coerced[i] = coerceJSON(item, t.Elem())
+	}
+	return coerced
+}
+
+func coerceMap(value any, t reflect.Type) any {
+	switch value := value.(type) {
+	case map[string]any:
+		coerced := make(map[string]any, len(value))
+		for key, item := range value {
+			coerced[key] = coerceJSON(item, t.Elem())
+		}
+		return coerced
+	case []any:
+		// PHP serializes empty associative arrays as empty lists
+		if len(value) == 0 {
+			return nil
+		}
+	}
+	return value
+}
+
+func coerceStruct(value any, t reflect.Type) any {
+	fields, ok := value.(map[string]any)
+	if !ok {
+		if list, isList := value.([]any); isList && len(list) == 0 {
+			return nil
+		}
+		return value
+	}
+
+	coerced := make(map[string]any, len(fields))
+	for key, item := range fields {
+		coerced[key] = item
+	}
+	coerceFields(coerced, t)
+	return coerced
+}
+
+func coerceFields(fields map[string]any, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		name, _, _ := strings.Cut(tag, ",")
+		if name == "-" || (!field.IsExported() && !field.Anonymous) {
+			continue
+		}
+
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			coerceFields(fields, field.Type)
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+		key, ok := fieldKey(fields, name)
+		if ok {
+			fields[key] = coerceJSON(fields[key], field.Type)
+		}
+	}
+}
+
+// fieldKey finds the key of a field the way encoding/json does, preferring an exact match.
+func fieldKey(fields map[string]any, name string) (string, bool) {
+	if _, ok := fields[name]; ok {
+		return name, true
+	}
+	for key := range fields {
+		if strings.EqualFold(key, name) {
+			return key, true
+		}
+	}
+	return "", false
+}
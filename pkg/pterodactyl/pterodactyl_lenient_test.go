@@ -0,0 +1,182 @@
+package pterodactyl
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+type lenientRecord struct {
+	Object     string `json:"object"`
+	Attributes struct {
+		ID        int               `json:"id"`
+		Memory    int64             `json:"memory"`
+		CPU       float64           `json:"cpu"`
+		Name      string            `json:"name"`
+		Public    bool              `json:"public"`
+		Suspended *bool             `json:"suspended"`
+		Ports     []int             `json:"ports"`
+		Labels    map[string]string `json:"labels"`
+		Limits    struct {
+			Disk int `json:"disk"`
+		} `json:"limits"`
+	} `json:"attributes"`
+}
+
+var lenientSeeds = []string{
+	`{"object": "node", "attributes": {"id": "7", "memory": "2048", "cpu": "1.5", "public": "1", "suspended": "0"}}`,
+	`{"attributes": {"id": 7, "public": 0, "suspended": 1, "name": 42}}`,
+	`{"attributes": {"public": "true", "ports": {"0": 25565, "2": "25567", "1": 25566}}}`,
+	`{"attributes": {"ports": null, "labels": null, "limits": null}}`,
+	`{"attributes": {"ports": [], "labels": [], "limits": []}}`,
+	`{"attributes": {"ports": "", "id": ""}}`,
+	`{"attributes": {"memory": "1e3", "id": "7.5"}}`,
+	`{"attributes": {"public": ""}}`,
+	`[]`,
+	`null`,
+}
+
+func TestDecodeLenient(t *testing.T) {
+	var record lenientRecord
+	err := decodeLenient([]byte(`{"object": "node", "attributes": {
+		"id": "7", "memory": " 2048 ", "cpu": "1.5", "name": 42, "public": "1", "suspended": "0",
+		"ports": {"1": "25566", "0": 25565}, "labels": [], "limits": {"disk": "10000"}}}`), &record)
+	if err != nil {
+		t.Fatalf("decodeLenient() error = %s", err)
+	}
+
+	attributes := record.Attributes
+	if attributes.ID != 7 || attributes.Memory != 2048 || attributes.CPU != 1.5 || attributes.Name != "42" || attributes.Limits.Disk != 10000 {
+		t.Errorf("decodeLenient() numbers and strings = %+v", attributes)
+	}
+	if !attributes.Public || attributes.Suspended == nil || *attributes.Suspended {
+		t.Errorf("decodeLenient() public = %t, suspended = %v, want true and false", attributes.Public, attributes.Suspended)
+	}
+	if !reflect.DeepEqual(attributes.Ports, []int{25565, 25566}) || attributes.Labels != nil {
+		t.Errorf("decodeLenient() ports = %v, labels = %v", attributes.Ports, attributes.Labels)
+	}
+}
+
+func TestDecodeLenientRejects(t *testing.T) {
+	tests := map[string]string{
+		"empty number":      `{"attributes": {"id": ""}}`,
+		"empty bool":        `{"attributes": {"public": ""}}`,
+		"fractional int":    `{"attributes": {"id": "7.5"}}`,
+		"unknown bool":      `{"attributes": {"public": "maybe"}}`,
+		"object for number": `{"attributes": {"memory": {}}}`,
+	}
+
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			var record lenientRecord
+			err := decodeLenient([]byte(data), &record)
+			if err == nil {
+				t.Errorf("decodeLenient() = %+v, want an error", record.Attributes)
+			}
+		})
+	}
+}
+
+func TestCoerceJSON(t *testing.T) {
+	tests := map[string]struct {
+		value    any
+		t        reflect.Type
+		expected any
+	}{
+		"number string":     {value: "42", t: reflect.TypeOf(0), expected: json.Number("42")},
+		"float string":      {value: "0.25", t: reflect.TypeOf(0.0), expected: json.Number("0.25")},
+		"bool as number":    {value: true, t: reflect.TypeOf(0), expected: json.Number("1")},
+		"empty number":      {value: "", t: reflect.TypeOf(0), expected: ""},
+		"bool string":       {value: "yes", t: reflect.TypeOf(false), expected: true},
+		"bool number":       {value: json.Number("0"), t: reflect.TypeOf(false), expected: false},
+		"empty bool":        {value: "", t: reflect.TypeOf(false), expected: ""},
+		"number for string": {value: json.Number("42"), t: reflect.TypeOf(""), expected: "42"},
+		"empty list":        {value: "", t: reflect.TypeOf([]int{}), expected: nil},
+		"empty map":         {value: []any{}, t: reflect.TypeOf(map[string]int{}), expected: nil},
+		"null":              {value: nil, t: reflect.TypeOf(0), expected: nil},
+		"unmarshaler":       {value: "2024", t: reflect.TypeOf(json.RawMessage{}), expected: "2024"},
+		"pointer":           {value: "1", t: reflect.TypeOf(new(bool)), expected: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if coerced := coerceJSON(test.value, test.t); !reflect.DeepEqual(coerced, test.expected) {
+				t.Errorf("coerceJSON(%#v, %s) = %#v, want %#v", test.value, test.t, coerced, test.expected)
+			}
+		})
+	}
+}
+
+func TestListResponseSkipsMalformedRecords(t *testing.T) {
+	var list ListResponse[lenientRecord]
+	err := json.Unmarshal([]byte(`{"object": "list", "data": [
+		{"attributes": {"id": "1"}},
+		{"attributes": {"id": ""}},
+		{"attributes": {"id": 3}},
+		"not a record"
+	]}`), &list)
+	if err != nil {
+		t.Fatalf("json.Unmarshal() error = %s", err)
+	}
+
+	if len(list.Data) != 2 || list.Data[0].Attributes.ID != 1 || list.Data[1].Attributes.ID != 3 {
+		t.Errorf("ListResponse.Data = %+v, want records 1 and 3", list.Data)
+	}
+	if len(list.Skipped) != 2 || list.Skipped[0].Index != 1 || list.Skipped[1].Index != 3 || list.Skipped[0].Err == nil {
+		t.Errorf("ListResponse.Skipped = %+v, want records at 1 and 3", list.Skipped)
+	}
+	if string(list.Skipped[1].Raw) != `"not a record"` {
+		t.Errorf("ListResponse.Skipped raw record = %s", list.Skipped[1].Raw)
+	}
+}
+
+func FuzzDecodeLenient(f *testing.F) {
+	for _, seed := range lenientSeeds {
+		f.Add([]byte(seed))
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var strict lenientRecord
+		strictErr := json.Unmarshal(data, &strict)
+
+		var lenient lenientRecord
+		err := decodeLenient(data, &lenient)
+
+		// Anything the standard decoder accepts is decoded the same way
+		if strictErr == nil && (err != nil || !reflect.DeepEqual(strict, lenient)) {
+			t.Errorf("decodeLenient() = %+v, %v, want %+v", lenient, err, strict)
+		}
+	})
+}
+
+func FuzzListResponseUnmarshal(f *testing.F) {
+	for _, seed := range lenientSeeds {
+		f.Add([]byte(`{"object": "list", "data": [` + seed + `]}`))
+	}
+	f.Add([]byte(`{"object": "list", "data": null}`))
+	f.Add([]byte(`{"object": "list", "data": [{"attributes": {"id": "1"}}, {"attributes": {"id": ""}}], "meta": {"pagination": {"total": "2"}}}`))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var list ListResponse[lenientRecord]
+		if json.Unmarshal(data, &list) != nil {
+			return
+		}
+
+		var envelope struct {
+			Data []json.RawMessage `json:"data"`
+		}
+		if decodeLenient(data, &envelope) != nil {
+			return
+		}
+
+		// Every record ends up either decoded or skipped
+		if len(list.Data)+len(list.Skipped) != len(envelope.Data) {
+			t.Errorf("ListResponse has %d records and %d skipped, want %d in total", len(list.Data), len(list.Skipped), len(envelope.Data))
+		}
+		for _, skipped := range list.Skipped {
+			if skipped.Err == nil || skipped.Index < 0 || skipped.Index >= len(envelope.Data) {
+				t.Errorf("ListResponse.Skipped has an invalid record %+v", skipped)
+			}
+		}
+	})
+}
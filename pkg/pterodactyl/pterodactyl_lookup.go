@@ -0,0 +1,66 @@
+package pterodactyl
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+var ErrServerNotFound = errors.New("server not found")
+
+func GetAllServers(pterodactylServer PterodactylServer) ([]Server, error) {
+	return getAllPages[Server](pterodactylServer, ApiEndpointServers, nil, url.Values{"include": {"egg"}})
+}
+
+// findServer returns the server whose key matches exactly, or else the only one starting with
+// it. Several exact matches are as ambiguous as several prefix matches.
+func findServer(servers []Server, value string, key func(server Server) string) (Server, error) {
+	var exact []Server
+	var prefixed []Server
+
+	for _, server := range servers {
+		switch {
+		case key(server) == value:
+			exact = append(exact, server)
+		case strings.HasPrefix(key(server), value):
+			prefixed = append(prefixed, server)
+		}
+	}
+
+	matches := exact
+	if len(matches) == 0 {
+		matches = prefixed
+	}
+
+	switch len(matches) {
+	case 0:
+		return Server{}, fmt.Errorf("%w: '%s'", ErrServerNotFound, value)
+	case 1:
+		return matches[0], nil
+	}
+
+	var names []string
+	for _, match := range matches {
+		names = append(names, fmt.Sprintf("%s (%s)", key(match), match.Attributes.UUID))
+	}
+	return Server{}, fmt.Errorf("'%s' matches several servers: %s", value, strings.Join(names, ", "))
+}
+
+func GetServerByName(pterodactylServer PterodactylServer, name string) (Server, error) {
+	servers, err := GetAllServers(pterodactylServer)
+	if err != nil {
+		return Server{}, err
+	}
+
+	return findServer(servers, name, func(server Server) string { return server.Attributes.Name })
+}
+
+func GetServerByIdentifier(pterodactylServer PterodactylServer, identifier string) (Server, error) {
+	servers, err := GetAllServers(pterodactylServer)
+	if err != nil {
+		return Server{}, err
+	}
+
+	return findServer(servers, identifier, func(server Server) string { return server.Attributes.Identifier })
+}
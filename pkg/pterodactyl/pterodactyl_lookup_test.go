@@ -0,0 +1,68 @@
+package pterodactyl
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestFindServer(t *testing.T) {
+	servers := []Server{
+		decodeServer(t, `{"attributes": {"uuid": "1", "name": "lobby"}}`),
+		decodeServer(t, `{"attributes": {"uuid": "2", "name": "lobby-2"}}`),
+		decodeServer(t, `{"attributes": {"uuid": "3", "name": "survival"}}`),
+		decodeServer(t, `{"attributes": {"uuid": "4", "name": "creative"}}`),
+		decodeServer(t, `{"attributes": {"uuid": "5", "name": "creative"}}`),
+	}
+	byName := func(server Server) string { return server.Attributes.Name }
+
+	tests := map[string]struct {
+		value    string
+		expected string
+		notFound bool
+	}{
+		"exact":            {value: "lobby", expected: "1"},
+		"exact and prefix": {value: "lobby-2", expected: "2"},
+		"unique prefix":    {value: "surv", expected: "3"},
+		"ambiguous prefix": {value: "lob"},
+		"ambiguous exact":  {value: "creative"},
+		"not found":        {value: "skyblock", notFound: true},
+		"case sensitive":   {value: "Lobby", notFound: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			server, err := findServer(servers, test.value, byName)
+			switch {
+			case test.expected != "":
+				if err != nil || server.Attributes.UUID != test.expected {
+					t.Errorf("findServer(%q) = %q, %v, want server %s", test.value, server.Attributes.UUID, err, test.expected)
+				}
+			case test.notFound:
+				if !errors.Is(err, ErrServerNotFound) {
+					t.Errorf("findServer(%q) error = %v, want %v", test.value, err, ErrServerNotFound)
+				}
+			default:
+				if err == nil || errors.Is(err, ErrServerNotFound) {
+					t.Errorf("findServer(%q) = %q, %v, want an ambiguity error", test.value, server.Attributes.UUID, err)
+				}
+			}
+		})
+	}
+}
+
+func TestGetServerByName(t *testing.T) {
+	pterodactylServer := panelServing(t, `{"data": [
+		{"attributes": {"uuid": "1", "identifier": "c9b1d8e2", "name": "lobby"}},
+		{"attributes": {"uuid": "2", "identifier": "f3a0c6d1", "name": "survival"}}
+	], "meta": {"pagination": {"total_pages": 1}}}`)
+
+	server, err := GetServerByName(pterodactylServer, "surv")
+	if err != nil || server.Attributes.UUID != "2" {
+		t.Errorf("GetServerByName() = %q, %v, want server 2", server.Attributes.UUID, err)
+	}
+
+	server, err = GetServerByIdentifier(pterodactylServer, "c9b1")
+	if err != nil || server.Attributes.UUID != "1" {
+		t.Errorf("GetServerByIdentifier() = %q, %v, want server 1", server.Attributes.UUID, err)
+	}
+}
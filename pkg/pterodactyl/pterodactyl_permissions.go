@@ -0,0 +1,232 @@
+package pterodactyl
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+type Permission string
+
+const (
+	PermissionWebsocketConnect Permission = "websocket.connect"
+
+	PermissionControlConsole Permission = "control.console"
+	PermissionControlStart   Permission = "control.start"
+	PermissionControlStop    Permission = "control.stop"
+	PermissionControlRestart Permission = "control.restart"
+
+	PermissionUserCreate Permission = "user.create"
+	PermissionUserRead   Permission = "user.read"
+	PermissionUserUpdate Permission = "user.update"
+	PermissionUserDelete Permission = "user.delete"
+
+	PermissionFileCreate      Permission = "file.create"
+	PermissionFileRead        Permission = "file.read"
+	PermissionFileReadContent Permission = "file.read-content"
+	PermissionFileUpdate      Permission = "file.update"
+	PermissionFileDelete      Permission = "file.delete"
+	PermissionFileArchive     Permission = "file.archive"
+	PermissionFileSftp        Permission = "file.sftp"
+
+	PermissionBackupCreate   Permission = "backup.create"
+	PermissionBackupRead     Permission = "backup.read"
+	PermissionBackupDelete   Permission = "backup.delete"
+	PermissionBackupDownload Permission = "backup.download"
+	PermissionBackupRestore  Permission = "backup.restore"
+
+	PermissionAllocationRead   Permission = "allocation.read"
+	PermissionAllocationCreate Permission = "allocation.create"
+	PermissionAllocationUpdate Permission = "allocation.update"
+	PermissionAllocationDelete Permission = "allocation.delete"
+
+	PermissionStartupRead        Permission = "startup.read"
+	PermissionStartupUpdate      Permission = "startup.update"
+	PermissionStartupDockerImage Permission = "startup.docker-image"
+
+	PermissionDatabaseCreate       Permission = "database.create"
+	PermissionDatabaseRead         Permission = "database.read"
+	PermissionDatabaseUpdate       Permission = "database.update"
+	PermissionDatabaseDelete       Permission = "database.delete"
+	PermissionDatabaseViewPassword Permission = "database.view_password"
+
+	PermissionScheduleCreate Permission = "schedule.create"
+	PermissionScheduleRead   Permission = "schedule.read"
+	PermissionScheduleUpdate Permission = "schedule.update"
+	PermissionScheduleDelete Permission = "schedule.delete"
+
+	PermissionSettingsRename    Permission = "settings.rename"
+	PermissionSettingsReinstall Permission = "settings.reinstall"
+
+	PermissionActivityRead Permission = "activity.read"
+)
+
+var (
+	allPermissions = []Permission{
+		PermissionWebsocketConnect,
+		PermissionControlConsole, PermissionControlStart, PermissionControlStop, PermissionControlRestart,
+		PermissionUserCreate, PermissionUserRead, PermissionUserUpdate, PermissionUserDelete,
+		PermissionFileCreate, PermissionFileRead, PermissionFileReadContent, PermissionFileUpdate, PermissionFileDelete, PermissionFileArchive, PermissionFileSftp,
+		PermissionBackupCreate, PermissionBackupRead, PermissionBackupDelete, PermissionBackupDownload, PermissionBackupRestore,
+		PermissionAllocationRead, PermissionAllocationCreate, PermissionAllocationUpdate, PermissionAllocationDelete,
+		PermissionStartupRead, PermissionStartupUpdate, PermissionStartupDockerImage,
+		PermissionDatabaseCreate, PermissionDatabaseRead, PermissionDatabaseUpdate, PermissionDatabaseDelete, PermissionDatabaseViewPassword,
+		PermissionScheduleCreate, PermissionScheduleRead, PermissionScheduleUpdate, PermissionScheduleDelete,
+		PermissionSettingsRename, PermissionSettingsReinstall,
+		PermissionActivityRead,
+	}
+
+	readOnlyPermissions = []Permission{
+		PermissionWebsocketConnect,
+		PermissionUserRead,
+		PermissionFileRead,
+		PermissionFileReadContent,
+		PermissionBackupRead,
+		PermissionAllocationRead,
+		PermissionStartupRead,
+		PermissionDatabaseRead,
+		PermissionScheduleRead,
+		PermissionActivityRead,
+	}
+)
+
+// The permission groups are returned as copies, callers may modify them freely.
+
+func AllPermissions() []Permission {
+	return append([]Permission(nil), allPermissions...)
+}
+
+func AllControlPermissions() []Permission {
+	return permissionsWithPrefix("control.")
+}
+
+func AllUserPermissions() []Permission {
+	return permissionsWithPrefix("user.")
+}
+
+func AllFilePermissions() []Permission {
+	return permissionsWithPrefix("file.")
+}
+
+func AllBackupPermissions() []Permission {
+	return permissionsWithPrefix("backup.")
+}
+
+func AllAllocationPermissions() []Permission {
+	return permissionsWithPrefix("allocation.")
+}
+
+func AllStartupPermissions() []Permission {
+	return permissionsWithPrefix("startup.")
+}
+
+func AllDatabasePermissions() []Permission {
+	return permissionsWithPrefix("database.")
+}
+
+func AllSchedulePermissions() []Permission {
+	return permissionsWithPrefix("schedule.")
+}
+
+func AllSettingsPermissions() []Permission {
+	return permissionsWithPrefix("settings.")
+}
+
+func ReadOnlyPermissions() []Permission {
+	return append([]Permission(nil), readOnlyPermissions...)
+}
+
+func permissionsWithPrefix(prefix string) []Permission {
+	var permissions []Permission
+	for _, permission := range allPermissions {
+		if strings.HasPrefix(string(permission), prefix) {
+			permissions = append(permissions, permission)
+		}
+	}
+	return permissions
+}
+
+func IsKnownPermission(permission Permission) bool {
+	for _, known := range allPermissions {
+		if known == permission {
+			return true
+		}
+	}
+	return false
+}
+
+type PermissionSet struct {
+	permissions map[Permission]bool
+}
+
+func NewPermissionSet(permissions ...Permission) *PermissionSet {
+	set := &PermissionSet{permissions: map[Permission]bool{}}
+	return set.Add(permissions...)
+}
+
+func (set *PermissionSet) Add(permissions ...Permission) *PermissionSet {
+	if set.permissions == nil {
+		set.permissions = map[Permission]bool{}
+	}
+	for _, permission := range permissions {
+		set.permissions[permission] = true
+	}
+	return set
+}
+
+func (set *PermissionSet) Remove(permissions ...Permission) *PermissionSet {
+	for _, permission := range permissions {
+		delete(set.permissions, permission)
+	}
+	return set
+}
+
+func (set *PermissionSet) Has(permission Permission) bool {
+	return set != nil && set.permissions[permission]
+}
+
+func (set *PermissionSet) List() []Permission {
+	var permissions []Permission
+	if set == nil {
+		return permissions
+	}
+
+	for permission := range set.permissions {
+		permissions = append(permissions, permission)
+	}
+
+	sort.Slice(permissions, func(i, j int) bool {
+		return permissions[i] < permissions[j]
+	})
+	return permissions
+}
+
+// Validate rejects nil or empty sets and permissions the panel does not know, which it would
+// otherwise drop silently.
+func (set *PermissionSet) Validate() error {
+	if set == nil {
+		return fmt.Errorf("permission set is nil")
+	}
+	if len(set.permissions) == 0 {
+		return fmt.Errorf("permission set is empty")
+	}
+
+	var unknown []string
+	for _, permission := range set.List() {
+		if !IsKnownPermission(permission) {
+			unknown = append(unknown, string(permission))
+		}
+	}
+
+	if len(unknown) > 0 {
+		return fmt.Errorf("unknown permissions: %s", strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+func (set *PermissionSet) formData(data map[string]string) map[string]string {
+	for i, permission := range set.List() {
+		data[fmt.Sprintf("permissions[%d]", i)] = string(permission)
+	}
+	return data
+}
@@ -0,0 +1,69 @@
+package pterodactyl
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestPermissionSetValidate(t *testing.T) {
+	tests := map[string]struct {
+		set   *PermissionSet
+		valid bool
+	}{
+		"nil":     {set: nil},
+		"empty":   {set: NewPermissionSet()},
+		"unknown": {set: NewPermissionSet(PermissionFileRead, Permission("file.execute"))},
+		"zero":    {set: (&PermissionSet{}).Add(PermissionFileRead), valid: true},
+		"known":   {set: NewPermissionSet(PermissionFileRead, PermissionBackupCreate), valid: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := test.set.Validate()
+			if (err == nil) != test.valid {
+				t.Errorf("Validate() error = %v, want valid = %t", err, test.valid)
+			}
+		})
+	}
+}
+
+func TestPermissionSetList(t *testing.T) {
+	set := NewPermissionSet(PermissionFileRead, PermissionBackupCreate, PermissionFileRead).Remove(PermissionBackupCreate)
+
+	if expected := []Permission{PermissionFileRead}; !reflect.DeepEqual(set.List(), expected) {
+		t.Errorf("List() = %v, want %v", set.List(), expected)
+	}
+	if expected := map[string]string{"permissions[0]": "file.read"}; !reflect.DeepEqual(set.formData(map[string]string{}), expected) {
+		t.Errorf("formData() = %v, want %v", set.formData(map[string]string{}), expected)
+	}
+}
+
+func TestCreateSubuserWithoutPermissions(t *testing.T) {
+	panel := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	}))
+	defer panel.Close()
+
+	server := decodeServer(t, `{"attributes": {"uuid": "c9b1d8e2-0000"}}`)
+	_, err := CreateSubuser(PterodactylServer{Url: panel.URL}, server, "user@example.com", nil)
+	if err == nil {
+		t.Error("CreateSubuser() with nil permissions error = nil, want an error")
+	}
+}
+
+func TestPermissionGroupsAreCopies(t *testing.T) {
+	permissions := AllBackupPermissions()
+	permissions[0] = Permission("backup.everything")
+
+	if AllBackupPermissions()[0] != PermissionBackupCreate {
+		t.Error("AllBackupPermissions() returned a shared slice")
+	}
+
+	readOnly := ReadOnlyPermissions()
+	readOnly[0] = PermissionFileDelete
+	if ReadOnlyPermissions()[0] != PermissionWebsocketConnect {
+		t.Error("ReadOnlyPermissions() returned a shared slice")
+	}
+}
@@ -0,0 +1,183 @@
+package pterodactyl
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+)
+
+type DeploymentRequest struct {
+	Memory            int
+	Disk              int
+	LocationIds       []int
+	ExcludeNodeIds    []int
+	AllowPrivateNodes bool
+}
+
+type DeploymentTarget struct {
+	Node       Node
+	Allocation Allocation
+}
+
+type nodeCandidate struct {
+	node  Node
+	score float64
+}
+
+// headroom returns the share of a node resource left after placing the requested amount,
+// or false when it does not fit. A negative overallocation disables the check on the panel.
+func headroom(limit int, overallocate int, used int, requested int) (float64, bool) {
+	if overallocate < 0 {
+		return 1, true
+	}
+
+	capacity := limit * (100 + overallocate) / 100
+	if capacity <= 0 {
+		return 0, false
+	}
+
+	free := capacity - used - requested
+	if free < 0 {
+		return 0, false
+	}
+
+	return float64(free) / float64(capacity), true
+}
+
+func (request DeploymentRequest) allowsLocation(locationId int) bool {
+	if len(request.LocationIds) == 0 {
+		return true
+	}
+
+	for _, id := range request.LocationIds {
+		if id == locationId {
+			return true
+		}
+	}
+	return false
+}
+
+func (request DeploymentRequest) excludesNode(nodeId int) bool {
+	for _, id := range request.ExcludeNodeIds {
+		if id == nodeId {
+			return true
+		}
+	}
+	return false
+}
+
+func rankNodes(nodes []Node, request DeploymentRequest) []nodeCandidate {
+	var candidates []nodeCandidate
+
+	for _, node := range nodes {
+		attributes := node.Attributes
+		if attributes.MaintenanceMode || (!attributes.Public && !request.AllowPrivateNodes) || !request.allowsLocation(attributes.LocationID) || request.excludesNode(attributes.ID) {
+			continue
+		}
+
+		memory, ok := headroom(attributes.Memory, attributes.MemoryOverallocate, attributes.AllocatedResources.Memory, request.Memory)
+		if !ok {
+			continue
+		}
+		disk, ok := headroom(attributes.Disk, attributes.DiskOverallocate, attributes.AllocatedResources.Disk, request.Disk)
+		if !ok {
+			continue
+		}
+
+		score := memory
+		if disk < score {
+			score = disk
+		}
+		candidates = append(candidates, nodeCandidate{node: node, score: score})
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].score > candidates[j].score
+	})
+	return candidates
+}
+
+// DeploymentPlanner keeps track of its own placements so several servers can be planned
+// before any of them is created on the panel.
+type DeploymentPlanner struct {
+	pterodactylServer PterodactylServer
+
+	mutex sync.Mutex
+	nodes []Node
+	pools map[int]*AllocationPool
+}
+
+func NewDeploymentPlanner(pterodactylServer PterodactylServer) (*DeploymentPlanner, error) {
+	nodes, err := GetNodes(pterodactylServer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DeploymentPlanner{
+		pterodactylServer: pterodactylServer,
+		nodes:             nodes,
+		pools:             map[int]*AllocationPool{},
+	}, nil
+}
+
+func (planner *DeploymentPlanner) pool(nodeId int) (*AllocationPool, error) {
+	pool, ok := planner.pools[nodeId]
+	if ok {
+		return pool, nil
+	}
+
+	pool, err := NewAllocationPool(planner.pterodactylServer, nodeId)
+	if err != nil {
+		return nil, err
+	}
+
+	planner.pools[nodeId] = pool
+	return pool, nil
+}
+
+func (planner *DeploymentPlanner) Plan(request DeploymentRequest) (DeploymentTarget, error) {
+	var target DeploymentTarget
+
+	planner.mutex.Lock()
+	defer planner.mutex.Unlock()
+
+	for _, candidate := range rankNodes(planner.nodes, request) {
+		pool, err := planner.pool(candidate.node.Attributes.ID)
+		if err != nil {
+			return target, err
+		}
+
+		allocations, err := pool.Reserve(1)
+		if err != nil {
+			log.Debugf("PlanDeployment -> Node '%s' has capacity but no free allocation", candidate.node.Attributes.Name)
+			continue
+		}
+
+		for i := range planner.nodes {
+			if planner.nodes[i].Attributes.ID == candidate.node.Attributes.ID {
+				planner.nodes[i].Attributes.AllocatedResources.Memory += request.Memory
+				planner.nodes[i].Attributes.AllocatedResources.Disk += request.Disk
+			}
+		}
+
+		allocation := allocations[0]
+		log.Trace(fmt.Sprintf("PlanDeployment -> Selected node '%s' with allocation %s:%d", candidate.node.Attributes.Name, allocation.Attributes.IP, allocation.Attributes.Port))
+		target.Node = candidate.node
+		target.Allocation = allocation
+		return target, nil
+	}
+
+	return target, errors.New("no node has enough capacity and a free allocation")
+}
+
+func PlanDeployment(pterodactylServer PterodactylServer, request DeploymentRequest) (DeploymentTarget, error) {
+	planner, err := NewDeploymentPlanner(pterodactylServer)
+	if err != nil {
+		return DeploymentTarget{}, err
+	}
+
+	return planner.Plan(request)
+}
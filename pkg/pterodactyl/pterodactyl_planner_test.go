@@ -0,0 +1,127 @@
+package pterodactyl
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func plannerNode(t *testing.T, id int, memory int, usedMemory int, disk int, usedDisk int, extra string) Node {
+	t.Helper()
+
+	var node Node
+	err := json.Unmarshal([]byte(fmt.Sprintf(`{"attributes": {"id": %d, "name": "node-%d", "public": true, "memory": %d, "disk": %d,
+		"allocated_resources": {"memory": %d, "disk": %d}%s}}`, id, id, memory, disk, usedMemory, usedDisk, extra)), &node)
+	if err != nil {
+		t.Fatalf("decoding node: %s", err)
+	}
+	return node
+}
+
+func TestHeadroom(t *testing.T) {
+	tests := map[string]struct {
+		limit, overallocate, used, requested int
+		expected                             float64
+		fits                                 bool
+	}{
+		"half free":     {limit: 1000, used: 250, requested: 250, expected: 0.5, fits: true},
+		"exactly full":  {limit: 1000, used: 500, requested: 500, expected: 0, fits: true},
+		"too small":     {limit: 1000, used: 900, requested: 200, fits: false},
+		"overallocated": {limit: 1000, overallocate: 50, used: 900, requested: 300, expected: 0.2, fits: true},
+		"unlimited":     {limit: 1000, overallocate: -1, used: 5000, requested: 5000, expected: 1, fits: true},
+		"no capacity":   {limit: 0, used: 0, requested: 0, fits: false},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			score, fits := headroom(test.limit, test.overallocate, test.used, test.requested)
+			if fits != test.fits || (fits && score != test.expected) {
+				t.Errorf("headroom() = %v, %t, want %v, %t", score, fits, test.expected, test.fits)
+			}
+		})
+	}
+}
+
+func TestRankNodes(t *testing.T) {
+	nodes := []Node{
+		// 80% of the memory but only 10% of the disk left
+		plannerNode(t, 1, 10000, 2000, 10000, 9000, ""),
+		// 50% of both left
+		plannerNode(t, 2, 10000, 5000, 10000, 5000, ""),
+		plannerNode(t, 3, 10000, 0, 10000, 0, `, "maintenance_mode": true`),
+		plannerNode(t, 4, 10000, 0, 10000, 0, `, "public": false`),
+		plannerNode(t, 5, 10000, 0, 10000, 0, `, "location_id": 2`),
+		// Unlimited memory with 30% of the disk left
+		plannerNode(t, 6, 10000, 20000, 10000, 7000, `, "memory_overallocate": -1`),
+	}
+
+	tests := map[string]struct {
+		request  DeploymentRequest
+		expected []int
+	}{
+		"tighter resource ranks": {request: DeploymentRequest{}, expected: []int{5, 2, 6, 1}},
+		"private nodes":          {request: DeploymentRequest{AllowPrivateNodes: true}, expected: []int{4, 5, 2, 6, 1}},
+		"excluded node":          {request: DeploymentRequest{ExcludeNodeIds: []int{5, 2}}, expected: []int{6, 1}},
+		"location":               {request: DeploymentRequest{LocationIds: []int{0}}, expected: []int{2, 6, 1}},
+		"does not fit":           {request: DeploymentRequest{Disk: 2000}, expected: []int{5, 2, 6}},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var ids []int
+			for _, candidate := range rankNodes(nodes, test.request) {
+				ids = append(ids, candidate.node.Attributes.ID)
+			}
+			if !reflect.DeepEqual(ids, test.expected) {
+				t.Errorf("rankNodes() = %v, want %v", ids, test.expected)
+			}
+		})
+	}
+}
+
+func TestDeploymentPlannerCountsPlacements(t *testing.T) {
+	panel := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/allocations"):
+			fmt.Fprint(w, `{"data": [
+				{"attributes": {"id": 1, "ip": "10.0.0.1", "port": 25565}},
+				{"attributes": {"id": 2, "ip": "10.0.0.1", "port": 25566}},
+				{"attributes": {"id": 3, "ip": "10.0.0.1", "port": 25567}}
+			], "meta": {"pagination": {"total_pages": 1}}}`)
+		default:
+			fmt.Fprint(w, `{"data": [
+				{"attributes": {"id": 1, "name": "node-1", "public": true, "memory": 4000, "disk": 10000, "allocated_resources": {"memory": 0}}},
+				{"attributes": {"id": 2, "name": "node-2", "public": true, "memory": 4000, "disk": 10000, "allocated_resources": {"memory": 1000}}}
+			], "meta": {"pagination": {"total_pages": 1}}}`)
+		}
+	}))
+	defer panel.Close()
+
+	planner, err := NewDeploymentPlanner(PterodactylServer{Url: panel.URL})
+	if err != nil {
+		t.Fatalf("NewDeploymentPlanner() error = %s", err)
+	}
+
+	// Node 1 starts with more room, the placements planned on it make node 2 the better one
+	var placements []string
+	for i := 0; i < 4; i++ {
+		target, err := planner.Plan(DeploymentRequest{Memory: 1000})
+		if err != nil {
+			t.Fatalf("Plan() error = %s", err)
+		}
+		placements = append(placements, fmt.Sprintf("%d:%d", target.Node.Attributes.ID, target.Allocation.Attributes.Port))
+	}
+
+	if expected := []string{"1:25565", "1:25566", "2:25565", "1:25567"}; !reflect.DeepEqual(placements, expected) {
+		t.Errorf("Plan() placements = %v, want %v", placements, expected)
+	}
+
+	_, err = planner.Plan(DeploymentRequest{Memory: 3000})
+	if err == nil {
+		t.Error("Plan() without capacity left error = nil, want an error")
+	}
+}
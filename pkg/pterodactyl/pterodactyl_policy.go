@@ -0,0 +1,144 @@
+package pterodactyl
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	OperationDeleteServer          string = "delete_server"
+	OperationDeleteBackup          string = "delete_backup"
+	OperationRestoreBackupTruncate string = "restore_backup_truncate"
+)
+
+var ErrOperationBlocked = errors.New("destructive operation blocked by policy")
+
+type DestructiveOperation struct {
+	Kind       string
+	ServerName string
+	ServerUUID string
+	Target     string
+}
+
+type MaintenanceWindow struct {
+	// Days the window opens on, every day when empty.
+	Days []time.Weekday `json:"days,omitempty"`
+	// Start and End are "15:04" times of day, a window may span midnight.
+	Start    string `json:"start"`
+	End      string `json:"end"`
+	Timezone string `json:"timezone,omitempty"`
+}
+
+// DestructivePolicy guards delete and truncating restore calls. Operations outside every
+// window or against a protected server are blocked, unless Confirm is set and approves them.
+type DestructivePolicy struct {
+	Windows []MaintenanceWindow `json:"windows,omitempty"`
+	// ProtectedServers holds path.Match patterns checked against server names and UUIDs.
+	ProtectedServers []string                                  `json:"protectedServers,omitempty"`
+	Confirm          func(operation DestructiveOperation) bool `json:"-"`
+}
+
+func minutesOfDay(value string) (int, error) {
+	parsed, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, err
+	}
+	return parsed.Hour()*60 + parsed.Minute(), nil
+}
+
+func (window MaintenanceWindow) Contains(at time.Time) (bool, error) {
+	if window.Timezone != "" {
+		location, err := time.LoadLocation(window.Timezone)
+		if err != nil {
+			return false, err
+		}
+		at = at.In(location)
+	}
+
+	start, err := minutesOfDay(window.Start)
+	if err != nil {
+		return false, fmt.Errorf("invalid maintenance window start '%s': %w", window.Start, err)
+	}
+	end, err := minutesOfDay(window.End)
+	if err != nil {
+		return false, fmt.Errorf("invalid maintenance window end '%s': %w", window.End, err)
+	}
+
+	now := at.Hour()*60 + at.Minute()
+	day := at.Weekday()
+	if start > end && now < end {
+		// Early morning part of a window that opened the day before
+		day = (day + 6) % 7
+	}
+
+	if len(window.Days) > 0 {
+		opened := false
+		for _, windowDay := range window.Days {
+			opened = opened || windowDay == day
+		}
+		if !opened {
+			return false, nil
+		}
+	}
+
+	if start <= end {
+		return now >= start && now < end, nil
+	}
+	return now >= start || now < end, nil
+}
+
+func (policy *DestructivePolicy) violation(operation DestructiveOperation, at time.Time) (string, error) {
+	for _, pattern := range policy.ProtectedServers {
+		for _, value := range []string{operation.ServerName, operation.ServerUUID} {
+			matched, err := path.Match(pattern, value)
+			if err != nil {
+				return "", err
+			}
+			if matched && value != "" {
+				return fmt.Sprintf("server '%s' is protected by '%s'", operation.ServerName, pattern), nil
+			}
+		}
+	}
+
+	if len(policy.Windows) == 0 {
+		return "", nil
+	}
+
+	for _, window := range policy.Windows {
+		open, err := window.Contains(at)
+		if err != nil {
+			return "", err
+		}
+		if open {
+			return "", nil
+		}
+	}
+
+	return "outside of the maintenance windows", nil
+}
+
+func checkDestructiveOperation(pterodactylServer PterodactylServer, operation DestructiveOperation) error {
+	policy := pterodactylServer.Policy
+	if policy == nil {
+		return nil
+	}
+
+	reason, err := policy.violation(operation, pterodactylServer.clock().Now())
+	if err != nil {
+		return err
+	}
+	if reason == "" {
+		return nil
+	}
+
+	if policy.Confirm != nil && policy.Confirm(operation) {
+		log.Warnf("Policy -> %s on '%s' confirmed although %s", operation.Kind, operation.ServerName, reason)
+		return nil
+	}
+
+	return fmt.Errorf("%w: %s on '%s' %s", ErrOperationBlocked, operation.Kind, operation.ServerName, reason)
+}
@@ -0,0 +1,85 @@
+package pterodactyl
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestMaintenanceWindowContains(t *testing.T) {
+	// 2024-03-09 is a Saturday
+	saturday := func(hour int, minute int) time.Time {
+		return time.Date(2024, time.March, 9, hour, minute, 0, 0, time.UTC)
+	}
+
+	tests := map[string]struct {
+		window   MaintenanceWindow
+		at       time.Time
+		expected bool
+	}{
+		"inside":                   {window: MaintenanceWindow{Start: "02:00", End: "04:00"}, at: saturday(3, 0), expected: true},
+		"at the start":             {window: MaintenanceWindow{Start: "02:00", End: "04:00"}, at: saturday(2, 0), expected: true},
+		"at the end":               {window: MaintenanceWindow{Start: "02:00", End: "04:00"}, at: saturday(4, 0), expected: false},
+		"other day":                {window: MaintenanceWindow{Days: []time.Weekday{time.Sunday}, Start: "02:00", End: "04:00"}, at: saturday(3, 0), expected: false},
+		"before midnight":          {window: MaintenanceWindow{Days: []time.Weekday{time.Saturday}, Start: "22:00", End: "02:00"}, at: saturday(23, 0), expected: true},
+		"after midnight":           {window: MaintenanceWindow{Days: []time.Weekday{time.Friday}, Start: "22:00", End: "02:00"}, at: saturday(1, 0), expected: true},
+		"after midnight wrong day": {window: MaintenanceWindow{Days: []time.Weekday{time.Saturday}, Start: "22:00", End: "02:00"}, at: saturday(1, 0), expected: false},
+		"outside overnight":        {window: MaintenanceWindow{Start: "22:00", End: "02:00"}, at: saturday(12, 0), expected: false},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			open, err := test.window.Contains(test.at)
+			if err != nil {
+				t.Fatalf("Contains() error = %s", err)
+			}
+			if open != test.expected {
+				t.Errorf("Contains(%s) = %t, want %t", test.at, open, test.expected)
+			}
+		})
+	}
+}
+
+func TestMaintenanceWindowContainsTimezone(t *testing.T) {
+	if _, err := time.LoadLocation("Europe/Berlin"); err != nil {
+		t.Skipf("time zone database unavailable: %s", err)
+	}
+
+	window := MaintenanceWindow{Start: "02:00", End: "04:00", Timezone: "Europe/Berlin"}
+	open, err := window.Contains(time.Date(2024, time.March, 9, 2, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("Contains() error = %s", err)
+	}
+	if !open {
+		t.Error("Contains() of 03:00 in Berlin = false, want true")
+	}
+}
+
+func TestMaintenanceWindowContainsInvalid(t *testing.T) {
+	for _, window := range []MaintenanceWindow{
+		{Start: "2am", End: "04:00"},
+		{Start: "02:00", End: "25:00"},
+		{Start: "02:00", End: "04:00", Timezone: "Nowhere/Special"},
+	} {
+		_, err := window.Contains(time.Now())
+		if err == nil {
+			t.Errorf("Contains() of %+v error = nil, want an error", window)
+		}
+	}
+}
+
+func TestCheckDestructiveOperation(t *testing.T) {
+	operation := DestructiveOperation{Kind: OperationDeleteServer, ServerName: "lobby", ServerUUID: "c9b1d8e2-0000"}
+	policy := &DestructivePolicy{ProtectedServers: []string{"lob*"}}
+
+	err := checkDestructiveOperation(PterodactylServer{Policy: policy}, operation)
+	if !errors.Is(err, ErrOperationBlocked) {
+		t.Errorf("checkDestructiveOperation() of a protected server error = %v, want %v", err, ErrOperationBlocked)
+	}
+
+	policy.Confirm = func(operation DestructiveOperation) bool { return true }
+	err = checkDestructiveOperation(PterodactylServer{Policy: policy}, operation)
+	if err != nil {
+		t.Errorf("checkDestructiveOperation() of a confirmed operation error = %s", err)
+	}
+}
@@ -0,0 +1,82 @@
+package pterodactyl
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	ApiEndpointPower     string = "power"
+	ApiEndpointCommand   string = "command"
+	ApiEndpointResources string = "resources"
+
+	PowerSignalStart   string = "start"
+	PowerSignalStop    string = "stop"
+	PowerSignalRestart string = "restart"
+	PowerSignalKill    string = "kill"
+
+	PowerStateOffline  string = "offline"
+	PowerStateStarting string = "starting"
+	PowerStateRunning  string = "running"
+	PowerStateStopping string = "stopping"
+)
+
+var ErrWaitTimeout = errors.New("timed out waiting for server")
+
+func SendPowerSignal(pterodactylServer PterodactylServer, server Server, signal string) error {
+	var response struct{}
+	return callApi(&response, pterodactylServer, http.MethodPost, ApiEndpointServer, []string{server.Attributes.UUID, ApiEndpointPower}, map[string]string{"signal": signal})
+}
+
+func SendCommand(pterodactylServer PterodactylServer, server Server, command string) error {
+	var response struct{}
+	return callApi(&response, pterodactylServer, http.MethodPost, ApiEndpointServer, []string{server.Attributes.UUID, ApiEndpointCommand}, map[string]string{"command": command})
+}
+
+func ReinstallServer(pterodactylServer PterodactylServer, server Server) error {
+	var response struct{}
+	return callApi(&response, pterodactylServer, http.MethodPost, ApiEndpointServer, []string{server.Attributes.UUID, "settings", "reinstall"}, nil)
+}
+
+func GetServerResources(pterodactylServer PterodactylServer, server Server) (ServerResources, error) {
+	if err := requireFeature(pterodactylServer, FeatureResources); err != nil {
+		return ServerResources{}, err
+	}
+
+	var resources ItemResponse[ServerResources]
+	err := callApi(&resources, pterodactylServer, http.MethodGet, ApiEndpointServer, []string{server.Attributes.UUID, ApiEndpointResources}, nil)
+	if err != nil {
+		return resources.Data, err
+	}
+
+	return resources.Data, nil
+}
+
+func WaitForPowerState(pterodactylServer PterodactylServer, server Server, state string, optionFuncs ...WaitOption) error {
+	options := newWaitOptions(DefaultPowerStatePollInterval, optionFuncs)
+	clock := pterodactylServer.clock()
+	start := clock.Now()
+
+	for {
+		resources, err := GetServerResources(pterodactylServer, server)
+		if err != nil {
+			return err
+		}
+
+		if resources.Attributes.CurrentState == state {
+			return nil
+		}
+
+		if options.expired(clock, start) {
+			return fmt.Errorf("%w '%s' to reach state '%s', current state is '%s'", ErrWaitTimeout, server.Attributes.Name, state, resources.Attributes.CurrentState)
+		}
+
+		log.Debugf("Waiting for server '%s' to reach state '%s'...", server.Attributes.Name, state)
+		if !options.sleep(clock) {
+			return ErrWaitStopped
+		}
+	}
+}
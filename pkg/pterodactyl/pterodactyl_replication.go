@@ -0,0 +1,252 @@
+package pterodactyl
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+type ReplicationTarget interface {
+	// Name identifies the target in the replication ledger and must stay stable between runs.
+	Name() string
+	Replicate(server Server, backup Backup, archive io.Reader) error
+}
+
+type DirectoryTarget struct {
+	Path string
+}
+
+func (target DirectoryTarget) Name() string {
+	return fmt.Sprintf("directory:%s", target.Path)
+}
+
+func (target DirectoryTarget) Replicate(server Server, backup Backup, archive io.Reader) error {
+	directory := filepath.Join(target.Path, server.Attributes.UUID)
+	err := os.MkdirAll(directory, 0750)
+	if err != nil {
+		return err
+	}
+
+	destination := filepath.Join(directory, fmt.Sprintf("%s.tar.gz", backup.Attributes.UUID))
+	out, err := os.CreateTemp(directory, ".replicating-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(out.Name())
+
+	_, err = io.Copy(out, archive)
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(out.Name(), destination)
+}
+
+// PanelTarget uploads backups into a server on a secondary panel. With Decompress set the
+// archive is extracted in place afterwards, restoring the files on that server.
+type PanelTarget struct {
+	PterodactylServer PterodactylServer
+	Server            Server
+	Directory         string
+	Decompress        bool
+}
+
+func (target PanelTarget) Name() string {
+	return fmt.Sprintf("panel:%s/%s", target.PterodactylServer.Name, target.Server.Attributes.UUID)
+}
+
+func (target PanelTarget) Replicate(server Server, backup Backup, archive io.Reader) error {
+	directory := target.Directory
+	if directory == "" {
+		directory = "/"
+	}
+	name := fmt.Sprintf("%s.tar.gz", backup.Attributes.UUID)
+
+	err := UploadServerFile(target.PterodactylServer, target.Server, directory, name, archive)
+	if err != nil {
+		return err
+	}
+
+	if !target.Decompress {
+		return nil
+	}
+	return DecompressServerFile(target.PterodactylServer, target.Server, directory, name)
+}
+
+type ReplicationLedger struct {
+	path string
+
+	mutex   sync.Mutex
+	Entries map[string]map[string]time.Time `json:"entries"`
+}
+
+func LoadReplicationLedger(path string) (*ReplicationLedger, error) {
+	ledger := &ReplicationLedger{path: path, Entries: map[string]map[string]time.Time{}}
+
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return ledger, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(content, ledger)
+	if err != nil {
+		return nil, err
+	}
+	if ledger.Entries == nil {
+		ledger.Entries = map[string]map[string]time.Time{}
+	}
+
+	return ledger, nil
+}
+
+func (ledger *ReplicationLedger) Contains(target string, backupId string) bool {
+	ledger.mutex.Lock()
+	defer ledger.mutex.Unlock()
+
+	_, ok := ledger.Entries[target][backupId]
+	return ok
+}
+
+// Record uses the system clock, replicators record with the clock of their source panel.
+func (ledger *ReplicationLedger) Record(target string, backupId string) error {
+	return ledger.record(target, backupId, systemClock{}.Now())
+}
+
+func (ledger *ReplicationLedger) record(target string, backupId string, at time.Time) error {
+	ledger.mutex.Lock()
+	defer ledger.mutex.Unlock()
+
+	if ledger.Entries[target] == nil {
+		ledger.Entries[target] = map[string]time.Time{}
+	}
+	ledger.Entries[target][backupId] = at
+
+	if ledger.path == "" {
+		return nil
+	}
+
+	content, err := json.MarshalIndent(ledger, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	temporary := fmt.Sprintf("%s.tmp", ledger.path)
+	err = os.WriteFile(temporary, content, 0600)
+	if err != nil {
+		return err
+	}
+	return os.Rename(temporary, ledger.path)
+}
+
+type ReplicationResult struct {
+	Server Server
+	Backup Backup
+	Target string
+	Err    error
+}
+
+type Replicator struct {
+	Source  PterodactylServer
+	Targets []ReplicationTarget
+	Ledger  *ReplicationLedger
+}
+
+func NewReplicator(source PterodactylServer, ledgerPath string, targets ...ReplicationTarget) (*Replicator, error) {
+	ledger, err := LoadReplicationLedger(ledgerPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Replicator{Source: source, Targets: targets, Ledger: ledger}, nil
+}
+
+func (replicator *Replicator) replicate(server Server, backup Backup, target ReplicationTarget) error {
+	signed, err := newSignedUrl(func() (string, error) {
+		return GetServerBackupDownloadUrl(replicator.Source, server, backup.Attributes.UUID)
+	})
+	if err != nil {
+		return err
+	}
+
+	res, err := openSignedRange(context.Background(), replicator.Source, signed, 0, -1)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	err = target.Replicate(server, backup, res.Body)
+	if err != nil {
+		return err
+	}
+
+	return replicator.Ledger.record(target.Name(), backup.Attributes.UUID, replicator.Source.clock().Now())
+}
+
+// ReplicateServer copies every completed backup of a server that a target has not received yet.
+func (replicator *Replicator) ReplicateServer(server Server) ([]ReplicationResult, error) {
+	var results []ReplicationResult
+
+	if !replicator.Source.Supports(FeatureBackups) {
+		log.Debugf("Replicator -> Skipping '%s', the source panel does not support backups", server.Attributes.Name)
+		return nil, nil
+	}
+
+	backups, err := GetServerBackups(replicator.Source, server)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, backup := range backups {
+		if backup.Attributes.CompletedAt.IsZero() || !backup.Attributes.IsSuccessful {
+			continue
+		}
+
+		for _, target := range replicator.Targets {
+			if replicator.Ledger.Contains(target.Name(), backup.Attributes.UUID) {
+				continue
+			}
+
+			log.Debugf("Replicator -> Replicating backup '%s' of '%s' to '%s'", backup.Attributes.UUID, server.Attributes.Name, target.Name())
+			err = replicator.replicate(server, backup, target)
+			if err != nil {
+				log.Errorf("Replicator -> Failed to replicate backup '%s' to '%s': %s", backup.Attributes.UUID, target.Name(), err)
+			}
+			results = append(results, ReplicationResult{Server: server, Backup: backup, Target: target.Name(), Err: err})
+		}
+	}
+
+	return results, nil
+}
+
+// Watch replicates the servers every interval until stop is closed. Failed backups are not
+// recorded in the ledger and are retried on the next run.
+func (replicator *Replicator) Watch(servers []Server, interval time.Duration, stop <-chan struct{}) {
+	for {
+		for _, server := range servers {
+			_, err := replicator.ReplicateServer(server)
+			if err != nil {
+				log.Errorf("Replicator -> Failed to list backups of '%s': %s", server.Attributes.Name, err)
+			}
+		}
+
+		select {
+		case <-stop:
+			return
+		case <-replicator.Source.clock().After(interval):
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package pterodactyl
+
+import (
+	"net/http"
+	"strconv"
+)
+
+type ApiResponse struct {
+	StatusCode int
+	Header     http.Header
+}
+
+// RateLimit returns the request limit and the remaining requests announced by the panel.
+func (response ApiResponse) RateLimit() (limit int, remaining int, ok bool) {
+	limit, err := strconv.Atoi(response.Header.Get("X-RateLimit-Limit"))
+	if err != nil {
+		return 0, 0, false
+	}
+
+	remaining, err = strconv.Atoi(response.Header.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return 0, 0, false
+	}
+
+	return limit, remaining, true
+}
+
+// RequestId returns the first request id header set by the panel or a proxy in front of it.
+func (response ApiResponse) RequestId() string {
+	for _, header := range []string{"X-Request-Id", "X-Amzn-Trace-Id", "Cf-Ray"} {
+		if value := response.Header.Get(header); value != "" {
+			return value
+		}
+	}
+	return ""
+}
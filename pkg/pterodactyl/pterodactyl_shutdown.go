@@ -0,0 +1,167 @@
+package pterodactyl
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+type ShutdownOutcome string
+
+// DefaultShutdownGracePeriod is how long a server gets to stop when no grace period is set.
+const DefaultShutdownGracePeriod = time.Minute
+
+const (
+	ShutdownStopped        ShutdownOutcome = "stopped"
+	ShutdownKilled         ShutdownOutcome = "killed"
+	ShutdownAlreadyOffline ShutdownOutcome = "already_offline"
+	ShutdownFailed         ShutdownOutcome = "failed"
+	ShutdownSkipped        ShutdownOutcome = "skipped"
+)
+
+type ShutdownWarning struct {
+	// Before is how long before the first server is stopped the command is sent.
+	Before  time.Duration
+	Command string
+}
+
+type ShutdownOptions struct {
+	Warnings []ShutdownWarning
+	// GracePeriod is how long each server gets to stop, defaults to DefaultShutdownGracePeriod.
+	GracePeriod     time.Duration
+	KillAfterGrace  bool
+	ContinueOnError bool
+	// PollInterval is the pause between two power state checks, defaults to DefaultPowerStatePollInterval.
+	PollInterval time.Duration
+}
+
+type ShutdownResult struct {
+	Server   Server
+	Outcome  ShutdownOutcome
+	Duration time.Duration
+	Err      error
+}
+
+func (options ShutdownOptions) withDefaults() ShutdownOptions {
+	if options.GracePeriod <= 0 {
+		options.GracePeriod = DefaultShutdownGracePeriod
+	}
+	return options
+}
+
+func sendShutdownWarnings(pterodactylServer PterodactylServer, servers []Server, warnings []ShutdownWarning) {
+	if len(warnings) == 0 {
+		return
+	}
+
+	warnings = append([]ShutdownWarning(nil), warnings...)
+	sort.SliceStable(warnings, func(i, j int) bool {
+		return warnings[i].Before > warnings[j].Before
+	})
+
+	clock := pterodactylServer.clock()
+	start := clock.Now()
+	countdown := warnings[0].Before
+
+	for _, warning := range warnings {
+		wait := countdown - warning.Before - clock.Now().Sub(start)
+		if wait > 0 {
+			pterodactylServer.sleep(wait)
+		}
+
+		for _, server := range servers {
+			log.Trace(fmt.Sprintf("ShutdownServers -> Sending warning to '%s': '%s'", server.Attributes.Name, warning.Command))
+			err := SendCommand(pterodactylServer, server, warning.Command)
+			if err != nil {
+				log.Warnf("ShutdownServers -> Failed to send warning to '%s': %s", server.Attributes.Name, err)
+			}
+		}
+	}
+
+	wait := countdown - clock.Now().Sub(start)
+	if wait > 0 {
+		pterodactylServer.sleep(wait)
+	}
+}
+
+func shutdownServer(pterodactylServer PterodactylServer, server Server, options ShutdownOptions) ShutdownResult {
+	result := ShutdownResult{Server: server}
+
+	resources, err := GetServerResources(pterodactylServer, server)
+	if err != nil {
+		result.Outcome, result.Err = ShutdownFailed, err
+		return result
+	}
+	if resources.Attributes.CurrentState == PowerStateOffline {
+		result.Outcome = ShutdownAlreadyOffline
+		return result
+	}
+
+	err = SendPowerSignal(pterodactylServer, server, PowerSignalStop)
+	if err != nil {
+		result.Outcome, result.Err = ShutdownFailed, err
+		return result
+	}
+
+	err = WaitForPowerState(pterodactylServer, server, PowerStateOffline, WithMaxWait(options.GracePeriod), WithPollInterval(options.PollInterval))
+	if err == nil {
+		result.Outcome = ShutdownStopped
+		return result
+	}
+	if !errors.Is(err, ErrWaitTimeout) || !options.KillAfterGrace {
+		result.Outcome, result.Err = ShutdownFailed, err
+		return result
+	}
+
+	log.Warnf("ShutdownServers -> '%s' did not stop within %s, killing it", server.Attributes.Name, options.GracePeriod)
+	err = SendPowerSignal(pterodactylServer, server, PowerSignalKill)
+	if err == nil {
+		err = WaitForPowerState(pterodactylServer, server, PowerStateOffline, WithMaxWait(options.GracePeriod), WithPollInterval(options.PollInterval))
+	}
+	if err != nil {
+		result.Outcome, result.Err = ShutdownFailed, err
+		return result
+	}
+
+	result.Outcome = ShutdownKilled
+	return result
+}
+
+// ShutdownServers warns every server through its console, then stops them one by one in the
+// given order. A failed server stops the sequence unless ContinueOnError is set, the remaining
+// servers are then reported as skipped.
+func ShutdownServers(pterodactylServer PterodactylServer, servers []Server, options ShutdownOptions) []ShutdownResult {
+	var results []ShutdownResult
+	options = options.withDefaults()
+
+	// The power state is read from the resources, fail early rather than after the warnings
+	if err := requireFeature(pterodactylServer, FeatureResources); err != nil {
+		for _, server := range servers {
+			results = append(results, ShutdownResult{Server: server, Outcome: ShutdownFailed, Err: err})
+		}
+		return results
+	}
+
+	sendShutdownWarnings(pterodactylServer, servers, options.Warnings)
+
+	for i, server := range servers {
+		started := pterodactylServer.clock().Now()
+		result := shutdownServer(pterodactylServer, server, options)
+		result.Duration = pterodactylServer.clock().Now().Sub(started)
+		results = append(results, result)
+
+		log.Debugf("ShutdownServers -> '%s': %s", server.Attributes.Name, result.Outcome)
+
+		if result.Outcome == ShutdownFailed && !options.ContinueOnError {
+			for _, skipped := range servers[i+1:] {
+				results = append(results, ShutdownResult{Server: skipped, Outcome: ShutdownSkipped})
+			}
+			break
+		}
+	}
+
+	return results
+}
@@ -0,0 +1,51 @@
+package pterodactyl
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// powerPanel reports the states in turn on the resources endpoint and records power signals.
+func powerPanel(t *testing.T, states ...string) (PterodactylServer, *[]string) {
+	t.Helper()
+
+	var mutex sync.Mutex
+	var signals []string
+	panel := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/resources"):
+			fmt.Fprintf(w, `{"attributes": {"current_state": "%s"}}`, states[0])
+			if len(states) > 1 {
+				states = states[1:]
+			}
+		case strings.HasSuffix(r.URL.Path, "/power"):
+			signals = append(signals, r.FormValue("signal"))
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(panel.Close)
+
+	return PterodactylServer{Url: panel.URL, Clock: newFakeClock()}, &signals
+}
+
+func TestShutdownServersDefaultGracePeriod(t *testing.T) {
+	pterodactylServer, signals := powerPanel(t, PowerStateRunning, PowerStateStopping, PowerStateStopping, PowerStateOffline)
+	server := decodeServer(t, `{"attributes": {"uuid": "c9b1d8e2-0000", "name": "lobby"}}`)
+
+	results := ShutdownServers(pterodactylServer, []Server{server}, ShutdownOptions{KillAfterGrace: true})
+	if len(results) != 1 || results[0].Outcome != ShutdownStopped {
+		t.Fatalf("ShutdownServers() = %+v, want the server stopped", results)
+	}
+	if len(*signals) != 1 || (*signals)[0] != PowerSignalStop {
+		t.Errorf("ShutdownServers() sent signals %v, want only %s", *signals, PowerSignalStop)
+	}
+}
@@ -0,0 +1,191 @@
+package pterodactyl
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+type StartupOutcome string
+
+// DefaultStartupTimeout is how long a server gets to start and pass its health check when no
+// timeout is set.
+const DefaultStartupTimeout = 5 * time.Minute
+
+const (
+	StartupStarted        StartupOutcome = "started"
+	StartupAlreadyRunning StartupOutcome = "already_running"
+	StartupFailed         StartupOutcome = "failed"
+	StartupSkipped        StartupOutcome = "skipped"
+)
+
+type StartupUnit struct {
+	Server Server
+	// DependsOn lists the UUIDs or identifiers of servers that must be up before this one starts.
+	DependsOn []string
+	// HealthCheck is polled after the server reports running until it returns nil.
+	HealthCheck func(pterodactylServer PterodactylServer, server Server) error
+}
+
+type StartupOptions struct {
+	// Timeout is how long each server gets to start, defaults to DefaultStartupTimeout.
+	Timeout time.Duration
+	// PollInterval is the pause between two power state or health checks, defaults to
+	// DefaultPowerStatePollInterval.
+	PollInterval time.Duration
+}
+
+type StartupResult struct {
+	Server   Server
+	Outcome  StartupOutcome
+	Duration time.Duration
+	Err      error
+}
+
+// startupOrder sorts the units so every server comes after its dependencies, keeping the
+// declared order between independent servers.
+func startupOrder(units []StartupUnit) ([]int, [][]int, error) {
+	keys := map[string]int{}
+	for i, unit := range units {
+		keys[unit.Server.Attributes.UUID] = i
+		keys[unit.Server.Attributes.Identifier] = i
+	}
+	delete(keys, "")
+
+	dependencies := make([][]int, len(units))
+	for i, unit := range units {
+		for _, dependency := range unit.DependsOn {
+			index, ok := keys[dependency]
+			if !ok {
+				return nil, nil, fmt.Errorf("server '%s' depends on unknown server '%s'", unit.Server.Attributes.Name, dependency)
+			}
+			dependencies[i] = append(dependencies[i], index)
+		}
+	}
+
+	var order []int
+	placed := make([]bool, len(units))
+	for len(order) < len(units) {
+		progress := false
+
+		for i := range units {
+			if placed[i] {
+				continue
+			}
+
+			ready := true
+			for _, dependency := range dependencies[i] {
+				ready = ready && placed[dependency]
+			}
+			if ready {
+				placed[i] = true
+				order = append(order, i)
+				progress = true
+			}
+		}
+
+		if !progress {
+			var cycle []string
+			for i, unit := range units {
+				if !placed[i] {
+					cycle = append(cycle, unit.Server.Attributes.Name)
+				}
+			}
+			return nil, nil, fmt.Errorf("dependency cycle between servers: %s", strings.Join(cycle, ", "))
+		}
+	}
+
+	return order, dependencies, nil
+}
+
+func waitForHealthy(pterodactylServer PterodactylServer, unit StartupUnit, deadline time.Time, interval time.Duration) error {
+	if interval <= 0 {
+		interval = DefaultPowerStatePollInterval
+	}
+
+	for {
+		err := unit.HealthCheck(pterodactylServer, unit.Server)
+		if err == nil {
+			return nil
+		}
+
+		if pterodactylServer.clock().Now().After(deadline) {
+			return fmt.Errorf("%w '%s' to pass its health check: %s", ErrWaitTimeout, unit.Server.Attributes.Name, err)
+		}
+
+		log.Debugf("Waiting for server '%s' to pass its health check...", unit.Server.Attributes.Name)
+		pterodactylServer.sleep(interval)
+	}
+}
+
+func startServer(pterodactylServer PterodactylServer, unit StartupUnit, options StartupOptions) StartupResult {
+	result := StartupResult{Server: unit.Server, Outcome: StartupStarted}
+	deadline := pterodactylServer.clock().Now().Add(options.Timeout)
+
+	resources, err := GetServerResources(pterodactylServer, unit.Server)
+	if err != nil {
+		result.Outcome, result.Err = StartupFailed, err
+		return result
+	}
+
+	if resources.Attributes.CurrentState == PowerStateRunning {
+		result.Outcome = StartupAlreadyRunning
+	} else {
+		err = SendPowerSignal(pterodactylServer, unit.Server, PowerSignalStart)
+		if err == nil {
+			err = WaitForPowerState(pterodactylServer, unit.Server, PowerStateRunning, WithMaxWait(options.Timeout), WithPollInterval(options.PollInterval))
+		}
+	}
+
+	if err == nil && unit.HealthCheck != nil {
+		err = waitForHealthy(pterodactylServer, unit, deadline, options.PollInterval)
+	}
+	if err != nil {
+		result.Outcome, result.Err = StartupFailed, err
+	}
+
+	return result
+}
+
+// StartServersInOrder starts servers one at a time following their declared dependencies.
+// Servers depending on one that failed are skipped, the others are still started.
+func StartServersInOrder(pterodactylServer PterodactylServer, units []StartupUnit, options StartupOptions) ([]StartupResult, error) {
+	if err := requireFeature(pterodactylServer, FeatureResources); err != nil {
+		return nil, err
+	}
+	if options.Timeout <= 0 {
+		options.Timeout = DefaultStartupTimeout
+	}
+
+	order, dependencies, err := startupOrder(units)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]StartupResult, len(units))
+	up := make([]bool, len(units))
+
+	for _, i := range order {
+		unit := units[i]
+
+		blocked := false
+		for _, dependency := range dependencies[i] {
+			blocked = blocked || !up[dependency]
+		}
+		if blocked {
+			results[i] = StartupResult{Server: unit.Server, Outcome: StartupSkipped}
+			continue
+		}
+
+		started := pterodactylServer.clock().Now()
+		results[i] = startServer(pterodactylServer, unit, options)
+		results[i].Duration = pterodactylServer.clock().Now().Sub(started)
+		up[i] = results[i].Err == nil
+
+		log.Debugf("StartServersInOrder -> '%s': %s", unit.Server.Attributes.Name, results[i].Outcome)
+	}
+
+	return results, nil
+}
@@ -0,0 +1,67 @@
+package pterodactyl
+
+import (
+	"reflect"
+	"testing"
+)
+
+func startupUnit(t *testing.T, identifier string, dependsOn ...string) StartupUnit {
+	t.Helper()
+
+	server := decodeServer(t, `{"attributes": {"identifier": "`+identifier+`", "uuid": "`+identifier+`-uuid", "name": "`+identifier+`"}}`)
+	return StartupUnit{Server: server, DependsOn: dependsOn}
+}
+
+func TestStartupOrder(t *testing.T) {
+	units := []StartupUnit{
+		startupUnit(t, "proxy", "lobby", "survival"),
+		startupUnit(t, "database"),
+		startupUnit(t, "lobby", "database"),
+		startupUnit(t, "survival", "database-uuid"),
+	}
+
+	order, dependencies, err := startupOrder(units)
+	if err != nil {
+		t.Fatalf("startupOrder() error = %s", err)
+	}
+
+	if expected := []int{1, 2, 3, 0}; !reflect.DeepEqual(order, expected) {
+		t.Errorf("startupOrder() order = %v, want %v", order, expected)
+	}
+	if expected := []int{2, 3}; !reflect.DeepEqual(dependencies[0], expected) {
+		t.Errorf("startupOrder() dependencies of proxy = %v, want %v", dependencies[0], expected)
+	}
+}
+
+func TestStartupOrderErrors(t *testing.T) {
+	tests := map[string][]StartupUnit{
+		"cycle": {
+			startupUnit(t, "a", "b"),
+			startupUnit(t, "b", "a"),
+		},
+		"unknown dependency": {
+			startupUnit(t, "a", "missing"),
+		},
+	}
+
+	for name, units := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, _, err := startupOrder(units)
+			if err == nil {
+				t.Error("startupOrder() error = nil, want an error")
+			}
+		})
+	}
+}
+
+func TestStartServersInOrderDefaultTimeout(t *testing.T) {
+	pterodactylServer, _ := powerPanel(t, PowerStateOffline, PowerStateStarting, PowerStateStarting, PowerStateRunning)
+
+	results, err := StartServersInOrder(pterodactylServer, []StartupUnit{startupUnit(t, "lobby")}, StartupOptions{})
+	if err != nil {
+		t.Fatalf("StartServersInOrder() error = %s", err)
+	}
+	if results[0].Outcome != StartupStarted || results[0].Err != nil {
+		t.Errorf("StartServersInOrder() = %+v, want the server started", results[0])
+	}
+}
@@ -0,0 +1,59 @@
+package pterodactyl
+
+const (
+	ServerStatusInstalling      string = "installing"
+	ServerStatusInstallFailed   string = "install_failed"
+	ServerStatusReinstallFailed string = "reinstall_failed"
+	ServerStatusSuspended       string = "suspended"
+	ServerStatusRestoringBackup string = "restoring_backup"
+)
+
+// Status returns the status of the server, empty when it can be acted on. See the
+// ServerStatus constants.
+func (server Server) Status() string {
+	status, _ := server.Attributes.Status.(string)
+	return status
+}
+
+// UnmarshalJSON reconciles the state flags and the status, depending on the panel version
+// only one of them is reported.
+func (server *Server) UnmarshalJSON(data []byte) error {
+	type serverAlias Server
+	var decoded serverAlias
+
+	err := decodeLenient(data, &decoded)
+	if err != nil {
+		return err
+	}
+
+	*server = Server(decoded)
+	switch server.Status() {
+	case ServerStatusSuspended:
+		server.Attributes.IsSuspended = true
+	case ServerStatusInstalling:
+		server.Attributes.IsInstalling = true
+	}
+	return nil
+}
+
+// IsOperational reports whether the panel accepts power, file and backup actions on the
+// server, it refuses them while the server is suspended, installing, transferring, restoring
+// a backup or its node is under maintenance.
+func (server Server) IsOperational() bool {
+	attributes := server.Attributes
+	return server.Status() == "" && !attributes.IsSuspended && !attributes.IsInstalling &&
+		!attributes.IsTransferring && !attributes.IsNodeUnderMaintenance
+}
+
+func (server Server) InstallFailed() bool {
+	status := server.Status()
+	return status == ServerStatusInstallFailed || status == ServerStatusReinstallFailed
+}
+
+func (server Server) IsRestoringBackup() bool {
+	return server.Status() == ServerStatusRestoringBackup
+}
+
+func (server ApplicationServer) IsOperational() bool {
+	return server.Attributes.Status == "" && !server.Attributes.Suspended
+}
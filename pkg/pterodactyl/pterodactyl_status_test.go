@@ -0,0 +1,43 @@
+package pterodactyl
+
+import (
+	"testing"
+)
+
+func TestServerStatus(t *testing.T) {
+	tests := map[string]struct {
+		data          string
+		status        string
+		operational   bool
+		installFailed bool
+	}{
+		"null":           {data: `{"attributes": {"status": null}}`, operational: true},
+		"missing":        {data: `{"attributes": {}}`, operational: true},
+		"suspended":      {data: `{"attributes": {"status": "suspended"}}`, status: ServerStatusSuspended},
+		"install failed": {data: `{"attributes": {"status": "install_failed"}}`, status: ServerStatusInstallFailed, installFailed: true},
+		"flag only":      {data: `{"attributes": {"status": null, "is_transferring": true}}`},
+		"not a string":   {data: `{"attributes": {"status": 1}}`, operational: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := decodeServer(t, test.data)
+			if status := server.Status(); status != test.status {
+				t.Errorf("Status() = %q, want %q", status, test.status)
+			}
+			if operational := server.IsOperational(); operational != test.operational {
+				t.Errorf("IsOperational() = %t, want %t", operational, test.operational)
+			}
+			if installFailed := server.InstallFailed(); installFailed != test.installFailed {
+				t.Errorf("InstallFailed() = %t, want %t", installFailed, test.installFailed)
+			}
+		})
+	}
+
+	if server := decodeServer(t, `{"attributes": {"status": "suspended"}}`); !server.Attributes.IsSuspended {
+		t.Error("decoding a suspended status did not set IsSuspended")
+	}
+	if server := decodeServer(t, `{"attributes": {"status": "installing"}}`); !server.Attributes.IsInstalling {
+		t.Error("decoding an installing status did not set IsInstalling")
+	}
+}
@@ -0,0 +1,68 @@
+package pterodactyl
+
+import (
+	"net/http"
+)
+
+const (
+	ApiEndpointUsers string = "users"
+)
+
+func GetSubusers(pterodactylServer PterodactylServer, server Server) ([]Subuser, error) {
+	if err := requireFeature(pterodactylServer, FeatureSubusers); err != nil {
+		return nil, err
+	}
+
+	var subusers ListResponse[Subuser]
+	err := callApi(&subusers, pterodactylServer, http.MethodGet, ApiEndpointServer, []string{server.Attributes.UUID, ApiEndpointUsers}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return subusers.Data, nil
+}
+
+func CreateSubuser(pterodactylServer PterodactylServer, server Server, email string, permissions *PermissionSet) (Subuser, error) {
+	if err := requireFeature(pterodactylServer, FeatureSubusers); err != nil {
+		return Subuser{}, err
+	}
+	if err := permissions.Validate(); err != nil {
+		return Subuser{}, err
+	}
+
+	var subuser ItemResponse[Subuser]
+	data := permissions.formData(map[string]string{"email": email})
+	err := callApi(&subuser, pterodactylServer, http.MethodPost, ApiEndpointServer, []string{server.Attributes.UUID, ApiEndpointUsers}, data)
+	if err != nil {
+		return subuser.Data, err
+	}
+
+	return subuser.Data, nil
+}
+
+func UpdateSubuser(pterodactylServer PterodactylServer, server Server, subuserId string, permissions *PermissionSet) (Subuser, error) {
+	if err := requireFeature(pterodactylServer, FeatureSubusers); err != nil {
+		return Subuser{}, err
+	}
+	if err := permissions.Validate(); err != nil {
+		return Subuser{}, err
+	}
+
+	var subuser ItemResponse[Subuser]
+	data := permissions.formData(map[string]string{})
+	err := callApi(&subuser, pterodactylServer, http.MethodPost, ApiEndpointServer, []string{server.Attributes.UUID, ApiEndpointUsers, subuserId}, data)
+	if err != nil {
+		return subuser.Data, err
+	}
+
+	return subuser.Data, nil
+}
+
+func DeleteSubuser(pterodactylServer PterodactylServer, server Server, subuserId string) error {
+	if err := requireFeature(pterodactylServer, FeatureSubusers); err != nil {
+		return err
+	}
+
+	var response struct{}
+	return callApi(&response, pterodactylServer, http.MethodDelete, ApiEndpointServer, []string{server.Attributes.UUID, ApiEndpointUsers, subuserId}, nil)
+}
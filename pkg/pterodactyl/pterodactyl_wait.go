@@ -0,0 +1,70 @@
+package pterodactyl
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	DefaultBackupPollInterval     = 5 * time.Second
+	DefaultPowerStatePollInterval = 2 * time.Second
+)
+
+var ErrWaitStopped = errors.New("wait stopped")
+
+type waitOptions struct {
+	pollInterval time.Duration
+	maxWait      time.Duration
+	limited      bool
+	stop         <-chan struct{}
+}
+
+type WaitOption func(options *waitOptions)
+
+// WithPollInterval sets the pause between two checks, non-positive values keep the default.
+func WithPollInterval(interval time.Duration) WaitOption {
+	return func(options *waitOptions) {
+		if interval > 0 {
+			options.pollInterval = interval
+		}
+	}
+}
+
+// WithMaxWait makes the wait fail with ErrWaitTimeout once the duration elapsed. Without it
+// backups are waited for indefinitely.
+func WithMaxWait(maxWait time.Duration) WaitOption {
+	return func(options *waitOptions) {
+		options.maxWait = maxWait
+		options.limited = true
+	}
+}
+
+// WithStop makes the wait return ErrWaitStopped once stop is closed.
+func WithStop(stop <-chan struct{}) WaitOption {
+	return func(options *waitOptions) {
+		options.stop = stop
+	}
+}
+
+func newWaitOptions(pollInterval time.Duration, optionFuncs []WaitOption) waitOptions {
+	options := waitOptions{pollInterval: pollInterval}
+	for _, option := range optionFuncs {
+		option(&options)
+	}
+	return options
+}
+
+// expired reports whether the max wait elapsed since start.
+func (options waitOptions) expired(clock Clock, start time.Time) bool {
+	return options.limited && clock.Now().Sub(start) > options.maxWait
+}
+
+// sleep pauses for the poll interval and reports false when the wait was stopped meanwhile.
+func (options waitOptions) sleep(clock Clock) bool {
+	select {
+	case <-options.stop:
+		return false
+	case <-clock.After(options.pollInterval):
+		return true
+	}
+}
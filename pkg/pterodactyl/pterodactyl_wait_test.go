@@ -0,0 +1,119 @@
+package pterodactyl
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// sequencePanel answers the requests to path with the given bodies in turn, the last one is
+// repeated once the others were sent.
+func sequencePanel(t *testing.T, path string, bodies ...string) PterodactylServer {
+	t.Helper()
+
+	var mutex sync.Mutex
+	panel := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, path) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		mutex.Lock()
+		body := bodies[0]
+		if len(bodies) > 1 {
+			bodies = bodies[1:]
+		}
+		mutex.Unlock()
+
+		fmt.Fprint(w, body)
+	}))
+	t.Cleanup(panel.Close)
+
+	return PterodactylServer{Url: panel.URL}
+}
+
+func TestWaitForPowerState(t *testing.T) {
+	clock := newFakeClock()
+	pterodactylServer := sequencePanel(t, "/resources",
+		`{"attributes": {"current_state": "stopping"}}`,
+		`{"attributes": {"current_state": "stopping"}}`,
+		`{"attributes": {"current_state": "offline"}}`,
+	)
+	pterodactylServer.Clock = clock
+	server := decodeServer(t, `{"attributes": {"uuid": "c9b1d8e2-0000", "name": "lobby"}}`)
+
+	err := WaitForPowerState(pterodactylServer, server, PowerStateOffline, WithPollInterval(time.Second))
+	if err != nil {
+		t.Fatalf("WaitForPowerState() error = %s", err)
+	}
+	if expected := []time.Duration{time.Second, time.Second}; !reflect.DeepEqual(clock.waits, expected) {
+		t.Errorf("WaitForPowerState() waited %v, want %v", clock.waits, expected)
+	}
+}
+
+func TestWaitForPowerStateTimeout(t *testing.T) {
+	clock := newFakeClock()
+	pterodactylServer := sequencePanel(t, "/resources", `{"attributes": {"current_state": "starting"}}`)
+	pterodactylServer.Clock = clock
+	server := decodeServer(t, `{"attributes": {"uuid": "c9b1d8e2-0000", "name": "lobby"}}`)
+
+	start := clock.Now()
+	err := WaitForPowerState(pterodactylServer, server, PowerStateRunning, WithMaxWait(time.Minute))
+	if !errors.Is(err, ErrWaitTimeout) {
+		t.Fatalf("WaitForPowerState() error = %v, want %v", err, ErrWaitTimeout)
+	}
+	if waited := clock.Now().Sub(start); waited <= time.Minute || waited > time.Minute+DefaultPowerStatePollInterval {
+		t.Errorf("WaitForPowerState() gave up after %s, want just over %s", waited, time.Minute)
+	}
+}
+
+func TestWaitForPowerStateStopped(t *testing.T) {
+	pterodactylServer := sequencePanel(t, "/resources", `{"attributes": {"current_state": "starting"}}`)
+	server := decodeServer(t, `{"attributes": {"uuid": "c9b1d8e2-0000", "name": "lobby"}}`)
+
+	stop := make(chan struct{})
+	close(stop)
+
+	err := WaitForPowerState(pterodactylServer, server, PowerStateRunning, WithPollInterval(time.Hour), WithStop(stop))
+	if !errors.Is(err, ErrWaitStopped) {
+		t.Errorf("WaitForPowerState() error = %v, want %v", err, ErrWaitStopped)
+	}
+}
+
+func TestWaitForBackup(t *testing.T) {
+	clock := newFakeClock()
+	pterodactylServer := sequencePanel(t, "/backups/b4c7",
+		`{"attributes": {"uuid": "b4c7", "completed_at": null}}`,
+		`{"attributes": {"uuid": "b4c7", "is_successful": true, "completed_at": "2024-03-09T12:00:10Z"}}`,
+	)
+	pterodactylServer.Clock = clock
+	server := decodeServer(t, `{"attributes": {"uuid": "c9b1d8e2-0000", "name": "lobby"}}`)
+
+	backup, err := WaitForBackup(pterodactylServer, server, "b4c7")
+	if err != nil {
+		t.Fatalf("WaitForBackup() error = %s", err)
+	}
+	if !backup.Attributes.IsSuccessful {
+		t.Error("WaitForBackup() returned an unsuccessful backup")
+	}
+	if expected := []time.Duration{DefaultBackupPollInterval}; !reflect.DeepEqual(clock.waits, expected) {
+		t.Errorf("WaitForBackup() waited %v, want %v", clock.waits, expected)
+	}
+}
+
+func TestWaitForBackupTimeout(t *testing.T) {
+	pterodactylServer := sequencePanel(t, "/backups/b4c7", `{"attributes": {"uuid": "b4c7", "completed_at": null}}`)
+	pterodactylServer.Clock = newFakeClock()
+	server := decodeServer(t, `{"attributes": {"uuid": "c9b1d8e2-0000", "name": "lobby"}}`)
+
+	_, err := WaitForBackup(pterodactylServer, server, "b4c7", WithMaxWait(time.Minute))
+	if !errors.Is(err, ErrWaitTimeout) {
+		t.Errorf("WaitForBackup() error = %v, want %v", err, ErrWaitTimeout)
+	}
+}
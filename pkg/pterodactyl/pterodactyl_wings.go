@@ -0,0 +1,111 @@
+package pterodactyl
+
+import (
+	"net/http"
+)
+
+const (
+	WingsEndpointSystem  string = "system"
+	WingsEndpointServers string = "servers"
+)
+
+// WingsNode talks to a node daemon directly, authenticated with the daemon token found in
+// the node configuration on the panel. Wings has no endpoint reporting the progress of a
+// transfer, it only streams it over the server websocket, so there is no transfer status
+// getter. Server.Attributes.IsTransferring on the client API tells whether one is running.
+type WingsNode struct {
+	Name  string `json:"name"`
+	Url   string `json:"url"`
+	Token string `json:"token"`
+	// HTTPClient sends the requests to wings, a client owned by the SDK is used when it is nil.
+	HTTPClient *http.Client `json:"-"`
+}
+
+type WingsSystemInformation struct {
+	Architecture  string `json:"architecture"`
+	CPUCount      int    `json:"cpu_count"`
+	KernelVersion string `json:"kernel_version"`
+	OS            string `json:"os"`
+	Version       string `json:"version"`
+}
+
+type WingsServer struct {
+	State       string `json:"state"`
+	IsSuspended bool   `json:"is_suspended"`
+	Utilization struct {
+		MemoryBytes      int64   `json:"memory_bytes"`
+		MemoryLimitBytes int64   `json:"memory_limit_bytes"`
+		CPUAbsolute      float64 `json:"cpu_absolute"`
+		Network          struct {
+			RxBytes int64 `json:"rx_bytes"`
+			TxBytes int64 `json:"tx_bytes"`
+		} `json:"network"`
+		Uptime    int64  `json:"uptime"`
+		State     string `json:"state"`
+		DiskBytes int64  `json:"disk_bytes"`
+	} `json:"utilization"`
+	Configuration struct {
+		UUID string `json:"uuid"`
+		Meta struct {
+			Name        string `json:"name"`
+			Description string `json:"description"`
+		} `json:"meta"`
+		Suspended   bool              `json:"suspended"`
+		Invocation  string            `json:"invocation"`
+		Environment map[string]any    `json:"environment"`
+		Labels      map[string]string `json:"labels"`
+		Allocations struct {
+			Default struct {
+				IP   string `json:"ip"`
+				Port int    `json:"port"`
+			} `json:"default"`
+			Mappings map[string][]int `json:"mappings"`
+		} `json:"allocations"`
+		Build struct {
+			MemoryLimit int64  `json:"memory_limit"`
+			Swap        int64  `json:"swap"`
+			IoWeight    int    `json:"io_weight"`
+			CPULimit    int64  `json:"cpu_limit"`
+			Threads     string `json:"threads"`
+			DiskSpace   int64  `json:"disk_space"`
+			OomDisabled bool   `json:"oom_disabled"`
+		} `json:"build"`
+		Container struct {
+			Image string `json:"image"`
+		} `json:"container"`
+	} `json:"configuration"`
+}
+
+func (node WingsNode) pterodactylServer() PterodactylServer {
+	return PterodactylServer{ApiKey: node.Token, Name: node.Name, Url: node.Url, HTTPClient: node.HTTPClient}
+}
+
+func GetWingsSystemInformation(node WingsNode) (WingsSystemInformation, error) {
+	var information WingsSystemInformation
+	err := callApi(&information, node.pterodactylServer(), http.MethodGet, WingsEndpointSystem, nil, nil)
+	if err != nil {
+		return information, err
+	}
+
+	return information, nil
+}
+
+func GetWingsServers(node WingsNode) ([]WingsServer, error) {
+	var servers []WingsServer
+	err := callApi(&servers, node.pterodactylServer(), http.MethodGet, WingsEndpointServers, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return servers, nil
+}
+
+func GetWingsServer(node WingsNode, serverUuid string) (WingsServer, error) {
+	var server WingsServer
+	err := callApi(&server, node.pterodactylServer(), http.MethodGet, WingsEndpointServers, []string{serverUuid}, nil)
+	if err != nil {
+		return server, err
+	}
+
+	return server, nil
+}
@@ -0,0 +1,59 @@
+package pterodactyl
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// wingsDaemon answers the wings endpoints it knows and refuses requests without the token.
+func wingsDaemon(t *testing.T, token string, bodies map[string]string) WingsNode {
+	t.Helper()
+
+	daemon := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+token {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		body, ok := bodies[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, body)
+	}))
+	t.Cleanup(daemon.Close)
+
+	return WingsNode{Name: "node-1", Url: daemon.URL, Token: token}
+}
+
+func TestWingsNode(t *testing.T) {
+	node := wingsDaemon(t, "daemon-token", map[string]string{
+		"/api/system":                `{"architecture": "amd64", "cpu_count": 8, "version": "1.11.8"}`,
+		"/api/servers":               `[{"state": "running", "configuration": {"uuid": "c9b1d8e2-0000"}}, {"state": "offline", "is_suspended": true}]`,
+		"/api/servers/c9b1d8e2-0000": `{"state": "running", "utilization": {"memory_bytes": 1024}, "configuration": {"uuid": "c9b1d8e2-0000", "meta": {"name": "lobby"}}}`,
+	})
+
+	information, err := GetWingsSystemInformation(node)
+	if err != nil || information.Version != "1.11.8" || information.CPUCount != 8 {
+		t.Errorf("GetWingsSystemInformation() = %+v, %v, want version 1.11.8 with 8 cpus", information, err)
+	}
+
+	servers, err := GetWingsServers(node)
+	if err != nil || len(servers) != 2 || servers[0].Configuration.UUID != "c9b1d8e2-0000" || !servers[1].IsSuspended {
+		t.Errorf("GetWingsServers() = %+v, %v, want both servers", servers, err)
+	}
+
+	server, err := GetWingsServer(node, "c9b1d8e2-0000")
+	if err != nil || server.Configuration.Meta.Name != "lobby" || server.Utilization.MemoryBytes != 1024 {
+		t.Errorf("GetWingsServer() = %+v, %v, want lobby", server, err)
+	}
+
+	node.Token = "panel-key"
+	_, err = GetWingsSystemInformation(node)
+	if !IsStatusCode(err, http.StatusUnauthorized) {
+		t.Errorf("GetWingsSystemInformation() with a wrong token error = %v, want status code %d", err, http.StatusUnauthorized)
+	}
+}